@@ -4,19 +4,28 @@ import (
 	"flag"
 	"fmt"
 	"github.com/linxlib/astp"
+	"github.com/linxlib/astp/constants"
+	"os"
 )
 
 var (
 	outFile string
+	lint    bool
+	strict  bool
 )
 
 func init() {
 	flag.StringVar(&outFile, "o", "gen.gz", "-o gen.gz")
+	flag.BoolVar(&lint, "lint", false, "-lint 检查注解和结构体标签, 有错误时退出码为1")
+	flag.BoolVar(&strict, "strict", false, "-strict 检查时警告也视为错误")
 }
 func main() {
 	flag.Parse()
 	p := &astp.Parser{}
-	p.Parse()
+	if err := p.Parse(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	if outFile == "" {
 		outFile = "gen.gz"
 	}
@@ -24,5 +33,17 @@ func main() {
 	if err != nil {
 		fmt.Println(err)
 	}
+	if lint {
+		failed := false
+		for _, d := range p.Lint() {
+			fmt.Printf("%s: %s [%s] %s\n", d.Pos, d.Level, d.Code, d.Message)
+			if d.Level == constants.DiagError || strict {
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	}
 	fmt.Println("complete!")
 }
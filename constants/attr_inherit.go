@@ -0,0 +1,10 @@
+package constants
+
+// AttrInherit 嵌入的上级结构的注解如何被下级结构继承
+type AttrInherit = string
+
+const (
+	InheritOverride AttrInherit = "override" // 下级结构没有该注解时继承, 有则以下级的为准 (默认)
+	InheritAlways   AttrInherit = "inherit"  // 总是继承, 与下级结构的同名注解并存
+	InheritNever    AttrInherit = "never"    // 不继承
+)
@@ -0,0 +1,15 @@
+package constants
+
+// AttrTarget 注解可以标注的元素
+type AttrTarget = string
+
+const (
+	TargetPackage   AttrTarget = "package"
+	TargetStruct    AttrTarget = "struct"
+	TargetInterface AttrTarget = "interface"
+	TargetMethod    AttrTarget = "method"
+	TargetFunction  AttrTarget = "function"
+	TargetField     AttrTarget = "field"
+	TargetParam     AttrTarget = "param"
+	TargetEnum      AttrTarget = "enum"
+)
@@ -3,6 +3,7 @@ package constants
 var AttrTypes = map[string]AttrType{
 	"BODY":      AT_BODY,
 	"QUERY":     AT_QUERY,
+	"HEADER":    AT_HEADER,
 	"PATH":      AT_PATH,
 	"PLAIN":     AT_PLAIN,
 	"XML":       AT_XML,
@@ -31,12 +32,14 @@ var AttrTypes = map[string]AttrType{
 	"ENTITY":     AT_ENTITY,
 	"TABLE":      AT_TABLE,
 	"DEPRECATED": AT_DEPRECATED,
+	"PARAM":      AT_PARAM,
 }
 
 // AttrNames maps AttrType values to their string representations
 var AttrNames = map[AttrType]string{
 	AT_BODY:       "BODY",
 	AT_QUERY:      "QUERY",
+	AT_HEADER:     "HEADER",
 	AT_PATH:       "PATH",
 	AT_PLAIN:      "PLAIN",
 	AT_XML:        "XML",
@@ -62,6 +65,7 @@ var AttrNames = map[AttrType]string{
 	AT_ENTITY:     "ENTITY",
 	AT_TABLE:      "TABLE",
 	AT_DEPRECATED: "DEPRECATED",
+	AT_PARAM:      "PARAM",
 }
 
 type AttrType = int
@@ -101,6 +105,7 @@ const (
 	AT_ENTITY
 	AT_TABLE //@Table
 	AT_DEPRECATED
+	AT_PARAM //@Param(id) path
 	// AT_CUSTOM
 	AT_CUSTOM
 )
@@ -0,0 +1,9 @@
+package constants
+
+type ChanDir = string
+
+const (
+	ChanBoth ChanDir = "both" // chan T
+	ChanSend ChanDir = "send" // chan<- T
+	ChanRecv ChanDir = "recv" // <-chan T
+)
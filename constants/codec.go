@@ -0,0 +1,10 @@
+package constants
+
+type Codec = string
+
+const (
+	CodecJSON Codec = "json" // encoding/json
+	CodecXML  Codec = "xml"  // encoding/xml
+	CodecYAML Codec = "yaml" // gopkg.in/yaml.v3
+	CodecForm Codec = "form" // gin 的 form 绑定
+)
@@ -0,0 +1,8 @@
+package constants
+
+type DiagLevel = string
+
+const (
+	DiagError   DiagLevel = "error"
+	DiagWarning DiagLevel = "warning"
+)
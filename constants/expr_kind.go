@@ -0,0 +1,15 @@
+package constants
+
+type ExprKind = string
+
+const (
+	ExprLit       ExprKind = "lit"       // 1 / "a" / 1.5
+	ExprComposite ExprKind = "composite" // Config{Port: 8080} / []int{1, 2}
+	ExprCall      ExprKind = "call"      // errors.New("not found")
+	ExprIdent     ExprKind = "ident"     // DefaultPort / true / nil
+	ExprSelector  ExprKind = "selector"  // http.StatusOK
+	ExprUnary     ExprKind = "unary"     // &Config{} / -1
+	ExprBinary    ExprKind = "binary"    // a + b
+	ExprFunc      ExprKind = "func"      // func() {}
+	ExprOther     ExprKind = "other"
+)
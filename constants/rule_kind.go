@@ -0,0 +1,17 @@
+package constants
+
+type RuleKind = string
+
+const (
+	RuleRequired  RuleKind = "required"  // required
+	RuleOmitEmpty RuleKind = "omitempty" // omitempty, 为零值时不校验其他规则
+	RuleMin       RuleKind = "min"       // min=1 / gte=1 / gt=0 (字符串/切片为长度)
+	RuleMax       RuleKind = "max"       // max=64 / lte=64 / lt=65
+	RuleLen       RuleKind = "len"       // len=6
+	RulePattern   RuleKind = "pattern"   // regexp=^[a-z]+$
+	RuleEnum      RuleKind = "enum"      // oneof=a b
+	RuleFormat    RuleKind = "format"    // email / url / uuid / datetime=2006-01-02
+	RuleDive      RuleKind = "dive"      // dive, 之后的规则作用于切片/map的元素
+	RuleOr        RuleKind = "or"        // rgb|rgba
+	RuleOther     RuleKind = "other"
+)
@@ -5,15 +5,12 @@ import (
 	"strings"
 )
 
-func trimComment(line string) string {
-	return strings.TrimLeft(strings.TrimLeft(line, "//"), " ")
-}
-
-func GetComments(cg *ast.CommentGroup) []string {
+// GetRawComments 只去掉 // 的注释行, 保留缩进
+func GetRawComments(cg *ast.CommentGroup) []string {
 	var result []string
 	if cg != nil && cg.List != nil {
 		for _, comment := range cg.List {
-			result = append(result, trimComment(comment.Text))
+			result = append(result, strings.TrimPrefix(comment.Text, "//"))
 		}
 	}
 	return result
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -59,6 +60,7 @@ func (p *Parser) Parse() error {
 		Timestamp:  time.Now().Unix(),
 		Generator:  "github.com/linxlib/astp",
 		Version:    "v0.4",
+		Annotation: types.Annotations.Defs(),
 	}
 	slog.Info("parsing project...", "mod", modPkg, "go version", modVersion)
 	parsers.ParseFile("main.go", p.Project)
@@ -67,6 +69,20 @@ func (p *Parser) Parse() error {
 	return nil
 }
 
+// Lint 返回解析时发现的问题(eg. 错误的结构体标签)和注解检查的结果
+func (p *Parser) Lint() []*types.Diagnostic {
+	result := make([]*types.Diagnostic, 0)
+	for _, file := range p.FileMap {
+		result = append(result, file.Diagnostic...)
+	}
+	result = append(result, p.LintAnnotations(nil)...)
+	// 按位置排序, 同一位置的保持检查的顺序
+	slices.SortStableFunc(result, func(a, b *types.Diagnostic) int {
+		return a.ComparePos(b)
+	})
+	return result
+}
+
 func (p *Parser) VisitStructByName(name string, filter func(s *types.Struct) bool, handler func(s *types.Struct)) {
 	for _, file := range p.FileMap {
 		for _, s := range file.Struct {
@@ -13,9 +13,6 @@ func getPackageType(pkgName string, typeName string, modPkg string) string {
 		return constants.PackageBuiltin
 	}
 	if pkgName == "" {
-		if internal.IsInternalGenericType(typeName) {
-			return constants.PackageBuiltin
-		}
 		return constants.PackageSamePackage
 	} else {
 		if strings.HasPrefix(pkgName, modPkg) {
@@ -206,13 +203,19 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 	}
 	switch spec := expr.(type) {
 	case *ast.Ident: //直接一个类型
-		root.Generic = internal.IsInternalGenericType(spec.Name)
 		root.PkgPath = ""
 		root.PkgName = ""
 		root.Name = spec.Name
 		root.FullName = spec.Name
 		root.Valid = true
-		root.PkgType = getPackageType("", spec.Name, root.ModPkg)
+		if root.IsTypeParam(spec.Name) {
+			// 泛型参数 由所在作用域(结构/接收器/函数)的声明决定, 而非名称
+			root.Generic = true
+			root.PkgType = constants.PackageBuiltin
+		} else {
+			root.Generic = false
+			root.PkgType = getPackageType("", spec.Name, root.ModPkg)
+		}
 		return
 	case *ast.SelectorExpr: //带包的类型
 		pkgName := spec.X.(*ast.Ident).Name
@@ -244,9 +247,10 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 		root.Valid = true
 		root.FullName = "[]" + root.FullName
 		return
-	case *ast.Ellipsis: // ...
+	case *ast.Ellipsis: // ... 可变参数, 仍按切片处理, 另外标记 Variadic
 		findPackageV2(spec.Elt, root)
 		root.Slice = true
+		root.Variadic = true
 		root.Valid = true
 		root.FullName = "[]" + root.FullName
 		return
@@ -257,13 +261,13 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 		root.PkgName = ""
 		root.FullName = "map"
 		root.Valid = true
-		child := types.NewTypePkgInfo(root.ModPkg, root.CurrentPkg, root.Imports)
+		child := root.NewChild()
 		child.Imports = root.Imports
 		child.ModPkg = root.ModPkg
 		findPackageV2(spec.Key, child)
 		root.Children = append(root.Children, child)
 		root.FullName += "[" + child.FullName + "]"
-		child1 := types.NewTypePkgInfo(root.ModPkg, root.CurrentPkg, root.Imports)
+		child1 := root.NewChild()
 		findPackageV2(spec.Value, child1)
 		root.Children = append(root.Children, child1)
 		root.FullName += child1.FullName
@@ -272,7 +276,7 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 		findPackageV2(spec.X, root) //主类型
 		root.Generic = true
 		root.Valid = true
-		child := types.NewTypePkgInfo(root.ModPkg, root.CurrentPkg, root.Imports)
+		child := root.NewChild()
 		findPackageV2(spec.Index, child) //泛型类型
 		child.Generic = true
 
@@ -284,7 +288,7 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 		root.Generic = true
 		var tpString []string
 		for _, indic := range spec.Indices {
-			child := types.NewTypePkgInfo(root.ModPkg, root.CurrentPkg, root.Imports)
+			child := root.NewChild()
 			findPackageV2(indic, child)
 			child.Generic = true
 			root.Children = append(root.Children, child)
@@ -301,14 +305,26 @@ func findPackageV2(expr ast.Expr, root *types.TypePkgInfo) {
 		root.Valid = true
 		root.PkgType = constants.PackageBuiltin
 		return
-	case *ast.ChanType:
-		root.Name = "chan"
+	case *ast.ChanType: //通道 chan T / <-chan T / chan<- T
+		findPackageV2(spec.Value, root)
+		root.Chan = true
 		root.Valid = true
-		root.PkgType = constants.PackageBuiltin
+		switch spec.Dir {
+		case ast.SEND:
+			root.ChanDir = constants.ChanSend
+			root.FullName = "chan<- " + root.FullName
+		case ast.RECV:
+			root.ChanDir = constants.ChanRecv
+			root.FullName = "<-chan " + root.FullName
+		default:
+			root.ChanDir = constants.ChanBoth
+			root.FullName = "chan " + root.FullName
+		}
 		return
 	case *ast.StructType:
-		if expr.(*ast.StructType).Fields == nil {
+		if spec.Fields == nil || len(spec.Fields.List) == 0 {
 			root.Name = "struct"
+			root.FullName = "struct{}"
 			root.Valid = true
 			root.PkgType = constants.PackageBuiltin
 			return
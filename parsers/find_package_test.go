@@ -0,0 +1,72 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/parser"
+	"testing"
+)
+
+func Test_findPackageV2Chan(t *testing.T) {
+	expr, _ := parser.ParseExpr("<-chan *Event")
+	info := types.NewTypePkgInfo("tests", "", nil)
+	findPackageV2(expr, info)
+	if !info.Valid || !info.Chan || info.ChanDir != constants.ChanRecv {
+		t.Fatalf("valid: %v, chan: %v, dir: %v", info.Valid, info.Chan, info.ChanDir)
+	}
+	if info.Name != "Event" || !info.Pointer {
+		t.Fatalf("name: %s, pointer: %v", info.Name, info.Pointer)
+	}
+	if info.FullName != "<-chan *Event" {
+		t.Fatalf("full name: %s", info.FullName)
+	}
+
+	expr, _ = parser.ParseExpr("chan<- int")
+	info = types.NewTypePkgInfo("tests", "", nil)
+	findPackageV2(expr, info)
+	if info.ChanDir != constants.ChanSend || info.PkgType != constants.PackageBuiltin {
+		t.Fatalf("dir: %v, package type: %v", info.ChanDir, info.PkgType)
+	}
+}
+
+func Test_findPackageV2Ellipsis(t *testing.T) {
+	expr, _ := parser.ParseExpr("func(a ...string)")
+	info := types.NewTypePkgInfo("tests", "", nil)
+	findPackageV2(expr.(*ast.FuncType).Params.List[0].Type, info)
+	if !info.Variadic || !info.Slice || info.Name != "string" {
+		t.Fatalf("variadic: %v, slice: %v, name: %s", info.Variadic, info.Slice, info.Name)
+	}
+
+	expr, _ = parser.ParseExpr("[]string")
+	info = types.NewTypePkgInfo("tests", "", nil)
+	findPackageV2(expr, info)
+	if info.Variadic || !info.Slice {
+		t.Fatalf("variadic: %v, slice: %v", info.Variadic, info.Slice)
+	}
+}
+
+func Test_findPackageV2TypeParamScope(t *testing.T) {
+	expr, _ := parser.ParseExpr("[]Item")
+	info := types.NewTypePkgInfo("tests", "", nil).WithTypeParams([]*types.TypeParam{{Type: "Item"}})
+	findPackageV2(expr, info)
+	if !info.Generic || info.PkgType != constants.PackageBuiltin {
+		t.Fatalf("generic: %v, package type: %v", info.Generic, info.PkgType)
+	}
+
+	// 未在作用域中声明的单字母类型是普通类型
+	expr, _ = parser.ParseExpr("S")
+	info = types.NewTypePkgInfo("tests", "", nil)
+	findPackageV2(expr, info)
+	if info.Generic || info.PkgType != constants.PackageSamePackage {
+		t.Fatalf("generic: %v, package type: %v", info.Generic, info.PkgType)
+	}
+
+	// 作用域会传递到类型实参中
+	expr, _ = parser.ParseExpr("Resp[map[string]V]")
+	info = types.NewTypePkgInfo("tests", "", nil).WithTypeParams([]*types.TypeParam{{Type: "V"}})
+	findPackageV2(expr, info)
+	if len(info.Children) != 1 || len(info.Children[0].Children) != 2 || !info.Children[0].Children[1].Generic {
+		t.Fatalf("children: %v", info.Children)
+	}
+}
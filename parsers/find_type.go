@@ -50,3 +50,18 @@ func findType(pkg string, name string, modDir string, modPkg string, proj *types
 
 	return nil
 }
+
+// parsePackageOnce 确保项目内的某个包已被解析
+// 用于仅在函数体中被引用的包(如泛型函数的调用), 这些包不会经由类型查找被解析
+func parsePackageOnce(pkg string, proj *types.Project) {
+	dir := getPackageDir(pkg, proj.BaseDir, proj.ModPkg)
+	if dir == "" {
+		return
+	}
+	for _, f := range proj.FileMap {
+		if f.Package.Path == pkg {
+			return
+		}
+	}
+	proj.Merge(parseDir(dir, proj))
+}
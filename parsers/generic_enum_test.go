@@ -0,0 +1,49 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/types"
+	"path/filepath"
+	"testing"
+)
+
+func Test_genericEnumRef(t *testing.T) {
+	base, _ := filepath.Abs("./tests")
+	proj := &types.Project{
+		BaseDir: base,
+		ModPkg:  "tests",
+	}
+	files := parseDir(filepath.Join(base, "generic_enum"), proj)
+	proj.AfterParseProj()
+	structs := make(map[string]*types.Struct)
+	var find *types.Function
+	for _, f := range files {
+		for _, s := range f.Struct {
+			structs[s.Name] = s
+		}
+		find = f.Function[0]
+	}
+	status := structs["Status"].Enum
+	holder := structs["Holder"]
+	if holder.Field[0].Enum != status || holder.Field[1].TypeParam[0].Enum != status {
+		t.Fatal(holder.Field[1].TypeParam[0].Package)
+	}
+	// 普通字段中的泛型结构已实例化
+	r := holder.Field[1].Struct
+	if r.Generic || r.Field[1].Type != "Status" || r.Field[1].Enum != status {
+		t.Fatal(r.Field[1].TypeName)
+	}
+	// 每个嵌入的泛型结构的类型实参都被记录
+	ctl := structs["Ctl"]
+	if len(ctl.TypeParam) != 2 || ctl.TypeParam[0].Embed != "Base" || ctl.TypeParam[0].OType != "E" ||
+		ctl.TypeParam[1].Embed != "Other" || ctl.TypeParam[1].Type != "string" {
+		t.Fatalf("type params: %v", ctl.TypeParam)
+	}
+	// 嵌入的泛型结构中提升的方法
+	get := ctl.Method[0]
+	if get.Param[0].Enum != status {
+		t.Fatal(get.Param[0].TypeName)
+	}
+	if find.Param[0].TypeParam[0].Enum != status {
+		t.Fatal(find.Param[0].TypeParam[0].Package)
+	}
+}
@@ -0,0 +1,41 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"path/filepath"
+	"testing"
+)
+
+func Test_LintAnnotations(t *testing.T) {
+	base, _ := filepath.Abs("./tests")
+	proj := &types.Project{
+		BaseDir: base,
+		ModPkg:  "tests",
+	}
+	parseDir(filepath.Join(base, "lint"), proj)
+	want := []string{
+		"lint/lint.go:6: UserCtl: unknown annotation @Controler, did you mean @Controller?",
+		"lint/lint.go:6: UserCtl: @GET cannot be used on struct",
+		"lint/lint.go:10: UserCtl.Age: @Body cannot be used on field",
+		"lint/lint.go:10: UserCtl.Age: @Body has too many arguments: 1, want at most 0",
+		"lint/lint.go:16: UserCtl.List: @POST conflicts with @GET",
+		"lint/lint.go:21: UserCtl.Detail: @Route missing argument path",
+		"lint/lint.go:26: Req: @Body is not repeatable",
+		"lint/lint.go:33: Get: @Param(nope) has no such parameter",
+		"lint/lint.go:33: Get(id): @GET cannot be used on param",
+		"lint/lint.go:44: Put(req): @GET cannot be used on param",
+	}
+	got := proj.LintAnnotations(nil)
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i, d := range got {
+		if d.String() != want[i] {
+			t.Fatal(d.String())
+		}
+	}
+	if got[0].Level != constants.DiagWarning || got[1].Level != constants.DiagError || got[1].Code != "misplaced-annotation" {
+		t.Fatal(got[0].Level, got[1].Code)
+	}
+}
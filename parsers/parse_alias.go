@@ -0,0 +1,79 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+)
+
+// parseUnderlying 解析类型定义/别名右侧的类型
+// eg. type UserPage = Page[User] / type UserPage Page[User] / type Set[T comparable] = map[T]struct{}
+func parseUnderlying(expr ast.Expr, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) *types.TypeParam {
+	info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(tps)
+	findPackageV2(expr, info)
+	if !info.Valid {
+		return nil
+	}
+	t := &types.TypeParam{
+		Type:     info.Name,
+		TypeName: info.FullName,
+		ElemType: constants.ElemStruct,
+		Pointer:  info.Pointer,
+		Slice:    info.Slice,
+		Package:  new(types.Package),
+	}
+	t.Package.Type = info.PkgType
+	t.Package.Path = info.PkgPath
+	t.Package.Name = info.PkgName
+	if info.PkgType == constants.PackageOtherPackage {
+		t.Struct = findType(info.PkgPath, info.Name, proj.BaseDir, proj.ModPkg, proj)
+	}
+	t.TypeParam = parseTypeArgs(info, proj)
+	return t
+}
+
+// handleStructThisUnderlying 补全同包的右侧类型, 并将其字段代入到当前结构
+// 别名与原类型完全相同(包括方法), 而类型定义仅继承字段
+func handleStructThisUnderlying(filesCopy map[string]*types.File, s *types.Struct) {
+	if s.Underlying == nil {
+		return
+	}
+	findThis := func(tp *types.TypeParam) {
+		if tp.Struct != nil || tp.Package == nil || tp.Package.Type != constants.PackageSamePackage {
+			return
+		}
+		for _, f := range filesCopy {
+			for _, s2 := range f.Struct {
+				if s2.Name == tp.Type && s2 != s {
+					handleStructThisUnderlying(filesCopy, s2)
+					tp.Struct = s2.Clone()
+					tp.Package = s2.Package.Clone()
+				}
+			}
+		}
+	}
+	findThis(s.Underlying)
+	var visit func(tps []*types.TypeParam)
+	visit = func(tps []*types.TypeParam) {
+		for _, tp := range tps {
+			findThis(tp)
+			visit(tp.TypeParam)
+		}
+	}
+	visit(s.Underlying.TypeParam)
+
+	target := s.Underlying.Struct
+	if target == nil || len(s.Field) > 0 {
+		return
+	}
+	if target.Generic && len(s.Underlying.TypeParam) > 0 {
+		target = types.NewInstantiator(nil, nil).Struct(target, s.Underlying.TypeParam)
+	} else {
+		target = target.CloneFull()
+	}
+	s.Underlying.Struct = target.Clone()
+	s.Field = types.CopySlice(target.Field)
+	if s.Alias {
+		s.Method = types.CopySlice(target.Method)
+	}
+}
@@ -0,0 +1,36 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/types"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseAlias(t *testing.T) {
+	base, _ := filepath.Abs("./tests")
+	proj := &types.Project{
+		BaseDir: base,
+		ModPkg:  "tests",
+	}
+	files := parseDir(filepath.Join(base, "alias"), proj)
+	var userPage, set *types.Struct
+	for _, f := range files {
+		for _, s := range f.Struct {
+			switch s.Name {
+			case "UserPage":
+				userPage = s
+			case "Set":
+				set = s
+			}
+		}
+	}
+	if userPage == nil || !userPage.Alias || userPage.Underlying.TypeName != "Page[*User]" {
+		t.Fatalf("UserPage: %v", userPage)
+	}
+	if len(userPage.Field) != 2 || userPage.Field[0].TypeName != "[]*User" || userPage.Field[0].Struct == nil {
+		t.Fatalf("fields: %v", userPage.Field)
+	}
+	if set == nil || !set.Alias || !set.Generic || set.Underlying.Type != "map" {
+		t.Fatalf("Set: %v", set)
+	}
+}
@@ -0,0 +1,257 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"unicode"
+)
+
+// constTypeResolver 解析常量 c 的表达式中类型转换的类型 eg. Kind(3) 中的 Kind, 无法识别时返回nil
+type constTypeResolver func(c *types.Const, typ ast.Expr) *types.Const
+
+// constEvaluator 常量表达式求值
+// 支持所有常量运算符, iota, 对同包其他常量的引用, 类型转换 eg. Kind(3) 以及 len("abc")
+type constEvaluator struct {
+	consts   map[string]*types.Const
+	values   map[string]constant.Value
+	visiting map[string]bool
+	resolve  constTypeResolver
+}
+
+func newConstEvaluator(consts []*types.Const, resolve constTypeResolver) *constEvaluator {
+	e := &constEvaluator{
+		consts:   make(map[string]*types.Const, len(consts)),
+		values:   make(map[string]constant.Value, len(consts)),
+		visiting: make(map[string]bool),
+		resolve:  resolve,
+	}
+	for _, c := range consts {
+		if c.Name != "_" {
+			e.consts[c.Name] = c
+		}
+	}
+	return e
+}
+
+// evalConsts 计算常量的值, 无法计算的(eg. 引用了其他包的常量)保持为nil
+// resolve 用于确定表达式中类型转换的类型
+func evalConsts(consts []*types.Const, resolve constTypeResolver) {
+	e := newConstEvaluator(consts, resolve)
+	for _, c := range consts {
+		v := e.value(c)
+		if v.Kind() == constant.Unknown {
+			continue
+		}
+		c.Value = constValue(v)
+		c.Exact = v.ExactString()
+	}
+	// 未标记类型的常量, 引用了有类型的常量或转换为命名类型时也是该类型 eg. All = Read | Write / U = Kind(3) << 2
+	for _, c := range consts {
+		if c.Type != "" || c.Expr == "" {
+			continue
+		}
+		if typed := e.typeOf(c); typed != nil {
+			c.Type = typed.Type
+			c.TypeName = typed.TypeName
+			c.TypePkgPath = typed.TypePkgPath
+			c.ElemType = constants.ElemEnum
+		}
+	}
+}
+
+// typeOf 返回决定常量类型的(有类型的)常量, 无类型常量返回nil
+func (e *constEvaluator) typeOf(c *types.Const) *types.Const {
+	if c.Type != "" {
+		if c.Type == "ignore" {
+			return nil
+		}
+		return c
+	}
+	if c.Expr == "" || e.visiting[c.Name] {
+		return nil
+	}
+	expr, err := parser.ParseExpr(c.Expr)
+	if err != nil {
+		return nil
+	}
+	e.visiting[c.Name] = true
+	defer delete(e.visiting, c.Name)
+	return e.exprType(c, expr)
+}
+
+// exprType 常量 c 的表达式(或其一部分)的类型, 无类型时返回nil
+func (e *constEvaluator) exprType(c *types.Const, expr ast.Expr) *types.Const {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return e.exprType(c, expr.X)
+	case *ast.UnaryExpr:
+		return e.exprType(c, expr.X)
+	case *ast.CallExpr:
+		// 转换为命名类型 eg. Kind(3)
+		if typ := conversionType(expr); typ != nil && e.resolve != nil {
+			return e.resolve(c, typ)
+		}
+	case *ast.Ident:
+		if ref, ok := e.consts[expr.Name]; ok {
+			return e.typeOf(ref)
+		}
+	case *ast.BinaryExpr:
+		switch expr.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			// 比较的结果为无类型的bool
+			return nil
+		case token.SHL, token.SHR:
+			// 移位的结果为左操作数的类型
+			return e.exprType(c, expr.X)
+		}
+		if typed := e.exprType(c, expr.X); typed != nil {
+			return typed
+		}
+		return e.exprType(c, expr.Y)
+	}
+	return nil
+}
+
+func (e *constEvaluator) value(c *types.Const) constant.Value {
+	if v, ok := e.values[c.Name]; ok && c.Name != "_" {
+		return v
+	}
+	if c.Expr == "" || e.visiting[c.Name] {
+		return constant.MakeUnknown()
+	}
+	expr, err := parser.ParseExpr(c.Expr)
+	if err != nil {
+		return constant.MakeUnknown()
+	}
+	e.visiting[c.Name] = true
+	defer delete(e.visiting, c.Name)
+	// 常量声明中 iota 即为其在 const 区域中的索引
+	v := e.eval(expr, int64(c.Index))
+	e.values[c.Name] = v
+	return v
+}
+
+func (e *constEvaluator) eval(expr ast.Expr, iota int64) (v constant.Value) {
+	defer func() {
+		// 类型不匹配的运算 eg. "a" + 1 会panic
+		if recover() != nil {
+			v = constant.MakeUnknown()
+		}
+	}()
+	unknown := constant.MakeUnknown()
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return constant.MakeFromLiteral(expr.Value, expr.Kind, 0)
+	case *ast.ParenExpr:
+		return e.eval(expr.X, iota)
+	case *ast.Ident:
+		switch expr.Name {
+		case "iota":
+			return constant.MakeInt64(iota)
+		case "true", "false":
+			return constant.MakeBool(expr.Name == "true")
+		}
+		if c, ok := e.consts[expr.Name]; ok {
+			return e.value(c)
+		}
+	case *ast.UnaryExpr:
+		x := e.eval(expr.X, iota)
+		if x.Kind() == constant.Unknown {
+			return unknown
+		}
+		return constant.UnaryOp(expr.Op, x, 0)
+	case *ast.BinaryExpr:
+		x, y := e.eval(expr.X, iota), e.eval(expr.Y, iota)
+		if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+			return unknown
+		}
+		switch expr.Op {
+		case token.SHL, token.SHR:
+			s, ok := constant.Uint64Val(constant.ToInt(y))
+			if !ok {
+				return unknown
+			}
+			return constant.Shift(x, expr.Op, uint(s))
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(x, expr.Op, y))
+		case token.QUO, token.REM:
+			if constant.Sign(y) == 0 {
+				return unknown
+			}
+			if expr.Op == token.QUO && x.Kind() == constant.Int && y.Kind() == constant.Int {
+				// 整数除法
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y)
+			}
+		}
+		return constant.BinaryOp(x, expr.Op, y)
+	case *ast.CallExpr:
+		if len(expr.Args) != 1 {
+			return unknown
+		}
+		x := e.eval(expr.Args[0], iota)
+		if x.Kind() == constant.Unknown {
+			return unknown
+		}
+		if conversionType(expr) != nil {
+			// 转换为自定义类型 eg. Kind(3) / pkg.Kind(3), 值不变
+			return x
+		}
+		if ident, ok := expr.Fun.(*ast.Ident); ok {
+			switch ident.Name {
+			case "len":
+				if x.Kind() == constant.String {
+					return constant.MakeInt64(int64(len(constant.StringVal(x))))
+				}
+			case "float32", "float64":
+				return constant.ToFloat(x)
+			case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+				return constant.ToInt(x)
+			case "string":
+				switch x.Kind() {
+				case constant.String:
+					return x
+				case constant.Int:
+					// 整数转为对应的字符 eg. string(rune(65)) -> "A", 超出范围的为 \uFFFD
+					if i, ok := constant.Int64Val(x); ok && i >= 0 && i <= unicode.MaxRune {
+						return constant.MakeString(string(rune(i)))
+					}
+					return constant.MakeString(string(unicode.ReplacementChar))
+				}
+			}
+		}
+		// 其他内置的转换和函数(eg. bool / complex / unsafe.Sizeof)不求值
+		return unknown
+	}
+	return unknown
+}
+
+// constValue 将常量值转为对应的Go值, 超出int64/uint64范围的整数使用其精确的字符串表示
+func constValue(v constant.Value) any {
+	switch v.Kind() {
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Int:
+		if i, ok := constant.Int64Val(v); ok {
+			if int64(int(i)) == i {
+				return int(i)
+			}
+			return i
+		}
+		if u, ok := constant.Uint64Val(v); ok {
+			return u
+		}
+		return v.ExactString()
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	case constant.Complex:
+		return v.String()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+)
+
+// parseConstraint 解析泛型类型参数的约束
+func parseConstraint(expr ast.Expr, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) *types.Constraint {
+	c := &types.Constraint{
+		Expr: gotypes.ExprString(expr),
+	}
+	switch spec := expr.(type) {
+	case *ast.Ident:
+		switch spec.Name {
+		case "any":
+			c.Any = true
+		case "comparable":
+			c.Comparable = true
+		default:
+			term := parseConstraintTerm(spec, tps, imports, proj)
+			if term.IsBuiltin() {
+				// T int
+				c.Terms = append(c.Terms, term)
+			} else {
+				// T Model
+				c.Ref = append(c.Ref, term)
+			}
+		}
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		// T fmt.Stringer / T constraints.Ordered / T Number[int]
+		c.Ref = append(c.Ref, parseConstraintTerm(spec, tps, imports, proj))
+	case *ast.UnaryExpr, *ast.BinaryExpr:
+		// T ~int / T ~int | ~string
+		c.Terms = append(c.Terms, parseConstraintTerms(spec, tps, imports, proj)...)
+	case *ast.InterfaceType:
+		if spec.Methods == nil || len(spec.Methods.List) == 0 {
+			c.Any = true
+			break
+		}
+		for _, field := range spec.Methods.List {
+			if _, ok := field.Type.(*ast.FuncType); ok {
+				c.Method = append(c.Method, parseInterface([]*ast.Field{field}, imports, proj)...)
+				continue
+			}
+			c.Merge(parseConstraint(field.Type, tps, imports, proj))
+		}
+	default:
+		// 其他写法 eg. T []byte
+		c.Terms = append(c.Terms, parseConstraintTerm(spec, tps, imports, proj))
+	}
+	return c
+}
+
+// parseConstraintTerms 解析类型集合 eg. ~int | ~string | float64
+func parseConstraintTerms(expr ast.Expr, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) []*types.ConstraintTerm {
+	switch spec := expr.(type) {
+	case *ast.BinaryExpr:
+		if spec.Op == token.OR {
+			result := parseConstraintTerms(spec.X, tps, imports, proj)
+			return append(result, parseConstraintTerms(spec.Y, tps, imports, proj)...)
+		}
+	case *ast.UnaryExpr:
+		if spec.Op == token.TILDE {
+			term := parseConstraintTerm(spec.X, tps, imports, proj)
+			term.Tilde = true
+			return []*types.ConstraintTerm{term}
+		}
+	case *ast.ParenExpr:
+		return parseConstraintTerms(spec.X, tps, imports, proj)
+	}
+	return []*types.ConstraintTerm{parseConstraintTerm(expr, tps, imports, proj)}
+}
+
+func parseConstraintTerm(expr ast.Expr, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) *types.ConstraintTerm {
+	term := &types.ConstraintTerm{
+		TypeName: gotypes.ExprString(expr),
+		Package:  new(types.Package),
+	}
+	info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(tps)
+	findPackageV2(expr, info)
+	if info.Valid {
+		term.Type = info.Name
+		term.Package.Type = info.PkgType
+		term.Package.Path = info.PkgPath
+		term.Package.Name = info.PkgName
+		if info.PkgType == constants.PackageOtherPackage {
+			term.Struct = findType(info.PkgPath, info.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+			if term.Struct != nil {
+				term.Package = term.Struct.Package.Clone()
+				term.Package.Type = info.PkgType
+			}
+		}
+	}
+	return term
+}
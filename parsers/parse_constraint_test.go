@@ -0,0 +1,41 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"testing"
+)
+
+func Test_parseConstraint(t *testing.T) {
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	expr, _ := parser.ParseExpr("~int | ~string | float64")
+	c := parseConstraint(expr, nil, nil, proj)
+	if !c.IsUnion() || len(c.Terms) != 3 {
+		t.Fatalf("terms: %v", c.Terms)
+	}
+	if !c.Terms[0].Tilde || c.Terms[0].Type != "int" || c.Terms[2].Tilde {
+		t.Fatalf("terms: %v", c.Terms)
+	}
+
+	expr, _ = parser.ParseExpr("comparable")
+	if c = parseConstraint(expr, nil, nil, proj); !c.Comparable || c.Any {
+		t.Fatalf("comparable: %v, any: %v", c.Comparable, c.Any)
+	}
+
+	expr, _ = parser.ParseExpr("interface{}")
+	if c = parseConstraint(expr, nil, nil, proj); !c.Any {
+		t.Fatalf("any: %v", c.Any)
+	}
+
+	expr, _ = parser.ParseExpr("interface{ ~int64; comparable; Model; Name() string }")
+	c = parseConstraint(expr, nil, nil, proj)
+	if len(c.Terms) != 1 || !c.Comparable || len(c.Ref) != 1 || len(c.Method) != 1 {
+		t.Fatalf("terms: %v, comparable: %v, ref: %v, method: %v", c.Terms, c.Comparable, c.Ref, c.Method)
+	}
+	if c.Ref[0].Type != "Model" || c.Method[0].Name != "Name" {
+		t.Fatalf("ref: %v, method: %v", c.Ref, c.Method)
+	}
+}
@@ -5,28 +5,30 @@ import (
 	"github.com/linxlib/astp/types"
 	"go/ast"
 	"go/token"
-	"strconv"
-	"strings"
+	gotypes "go/types"
 )
 
-func parseConst(af *ast.File, p *types.Package) []*types.Const {
+func parseConst(af *ast.File, p *types.Package, imports []*types.Import, proj *types.Project) []*types.Const {
 
 	consts := make([]*types.Const, 0)
 	for _, decl := range af.Decls {
 		switch decl := decl.(type) {
 		case *ast.GenDecl:
 			if decl.Tok == token.CONST {
-				// 当前const区域所属的类型
-				var constAreaType string
-				// 当前值
-				var curValue int
-				// 是否有iota
-				var hasIota bool
+				// 省略了类型和值的常量, 重复上一个常量的类型和表达式 eg. const ( A Kind = iota; B; C )
+				var lastType ast.Expr
+				var lastValues []ast.Expr
 				// 这里一个表示一个const区域
 				for idx, spec := range decl.Specs {
 
 					switch spec := spec.(type) {
 					case *ast.ValueSpec:
+						typ, values := spec.Type, spec.Values
+						if len(values) == 0 {
+							typ, values = lastType, lastValues
+						} else {
+							lastType, lastValues = typ, values
+						}
 						for i, v := range spec.Names {
 							vv := &types.Const{
 								Name:    v.Name,
@@ -35,81 +37,16 @@ func parseConst(af *ast.File, p *types.Package) []*types.Const {
 								Doc:     parseDoc(spec.Doc, v.Name),
 								Comment: parseDoc(spec.Comment, v.Name),
 							}
-							isEnum := false
-							// 标记了类型，则有可能是枚举
-							if spec.Type != nil {
-								isEnum = true
-								// 再看是否有iota
-								if spec.Values != nil && len(spec.Values) > 0 {
-									switch vvv := spec.Values[i].(type) {
-									case *ast.Ident:
-										if vvv.Name == "iota" {
-											vv.Value = 0
-											curValue = 0
-											hasIota = true
-											isEnum = true
-											vv.Iota = hasIota
-										}
-
-									case *ast.BasicLit:
-										vv.Value = strings.Trim(vvv.Value, `"`)
-										hasIota = false // 直接赋值
-										isEnum = true
-
-										vv.ElemType = constants.ElemEnum
-									case *ast.BinaryExpr:
-										switch btype := vvv.X.(type) {
-										case *ast.Ident:
-											if btype.Name == "iota" {
-												hasIota = true
-												isEnum = true
-												curValue = 0
-												switch vvv.Y.(*ast.BasicLit).Kind {
-												case token.INT:
-													temp, _ := strconv.Atoi(vvv.Y.(*ast.BasicLit).Value)
-													if vvv.Op == token.ADD {
-														curValue += temp
-													} else if vvv.Op == token.SUB {
-														curValue -= temp
-													}
-													//TODO: token.OR token.AND etc...
-													// eg. xx = iota << 2  || xxx = iota +1 | 1
-												default:
-													panic("unhandled constant type")
-												}
-												vv.Value = curValue
-											}
-										default:
-											panic("unhandled constant type")
-										}
-
-									}
-								}
-
-								if a, ok := spec.Type.(*ast.Ident); ok {
-									vv.Type = a.Name
-									vv.TypeName = a.Name
-									constAreaType = a.Name
-									isEnum = true
-								} else {
-									isEnum = false
-									vv.Type = "ignore"
-									vv.TypeName = "ignore"
-								}
-							} else {
-								if hasIota || constAreaType != "" {
-									isEnum = true
-									vv.Type = constAreaType
-									vv.TypeName = constAreaType
-									vv.Iota = hasIota
-									curValue++
-									vv.Value = curValue
-
-								}
+							var value ast.Expr
+							if i < len(values) {
+								value = values[i]
+								vv.Expr = gotypes.ExprString(value)
+								vv.Iota = hasIota(value)
 							}
-
-							if isEnum {
-								vv.ElemType = constants.ElemEnum
+							// 标记了类型则有可能是枚举, 类型也可以是其他包中的类型 eg. order.Status
+							// 转换为某个类型的(eg. Kind(3) << 2)在求值时确定类型
+							if typ != nil {
+								setConstType(vv, typ, p, imports, proj)
 							}
 							consts = append(consts, vv)
 
@@ -119,5 +56,65 @@ func parseConst(af *ast.File, p *types.Package) []*types.Const {
 			}
 		}
 	}
+	// 常量的值和类型转换的类型在 handleThisConst 中按包求值
 	return consts
 }
+
+// setConstType 按类型表达式设置常量的类型, 类型无法识别时标记为 ignore
+func setConstType(c *types.Const, typ ast.Expr, p *types.Package, imports []*types.Import, proj *types.Project) bool {
+	info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
+	findPackageV2(typ, info)
+	if !info.Valid {
+		c.Type = "ignore"
+		c.TypeName = "ignore"
+		return false
+	}
+	c.Type = info.Name
+	c.TypeName = info.FullName
+	c.TypePkgPath = info.PkgPath
+	if info.PkgType == constants.PackageSamePackage {
+		c.TypePkgPath = p.Path
+	}
+	c.ElemType = constants.ElemEnum
+	return true
+}
+
+// resolveConstType 类型转换(eg. Kind(3))中的类型, 无法识别时返回nil
+func resolveConstType(typ ast.Expr, p *types.Package, imports []*types.Import, proj *types.Project) *types.Const {
+	c := new(types.Const)
+	if !setConstType(c, typ, p, imports, proj) {
+		return nil
+	}
+	return c
+}
+
+// conversionType 转换为命名类型的调用中的类型 eg. Kind(3) / order.Status(1)
+// 内置类型和内置函数(eg. int64(1024) / len(Name))以及 unsafe.Sizeof 等返回nil
+func conversionType(call *ast.CallExpr) ast.Expr {
+	if len(call.Args) != 1 {
+		return nil
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if gotypes.Universe.Lookup(fun.Name) == nil {
+			return fun
+		}
+	case *ast.SelectorExpr:
+		if x, ok := fun.X.(*ast.Ident); !ok || x.Name != "unsafe" {
+			return fun
+		}
+	}
+	return nil
+}
+
+// hasIota 表达式中是否引用了iota
+func hasIota(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,81 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseConst(t *testing.T) {
+	node, _ := parser.ParseFile(token.NewFileSet(), "./tests/for_consts.txt", nil, parser.ParseComments)
+	consts := make(map[string]*types.Const)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	pkg := &types.Package{Path: "tests"}
+	file := &types.File{Package: pkg, Const: parseConst(node, pkg, nil, proj)}
+	// 常量按包求值
+	handleThisConst(map[string]*types.File{"for_consts.txt": file}, proj)
+	for _, c := range file.Const {
+		consts[c.Name] = c
+	}
+	cases := map[string]any{
+		"Read":  1,
+		"Write": 2,
+		"Exec":  4,
+		"All":   7,
+		"KindA": 3,
+		"KindB": 5,
+		"KB":    1024,
+		"MB":    1048576,
+		"Name":  "astp",
+		"Size":  4,
+		"Half":  3,
+		"Ok":    true,
+	}
+	for name, want := range cases {
+		if consts[name].Value != want {
+			t.Fatal(name, consts[name].Value)
+		}
+	}
+	if c := consts["Exec"]; c.Type != "Perm" || c.ElemType != constants.ElemEnum || !c.Iota || c.Expr != "1 << iota" {
+		t.Fatal(c.Type, c.Expr)
+	}
+	if c := consts["KindA"]; c.Type != "Kind" || c.ElemType != constants.ElemEnum {
+		t.Fatal(c.Type)
+	}
+	// 类型转换不在最外层时同样是该类型
+	if c := consts["KindC"]; c.Type != "Kind" || c.TypePkgPath != "tests" || c.ElemType != constants.ElemEnum || c.Value != 12 {
+		t.Fatal(c.Type, c.Value)
+	}
+	if c := consts["All"]; c.Type != "Perm" || c.TypePkgPath != "tests" || c.ElemType != constants.ElemEnum {
+		t.Fatal(c.Type)
+	}
+	if c := consts["Half"]; c.Type != "" || c.ElemType == constants.ElemEnum {
+		t.Fatal(c.Type)
+	}
+	if consts["Huge"].Value != "1267650600228229401496703205376" || consts["Ratio"].Exact != "1/3" {
+		t.Fatal(consts["Huge"].Value, consts["Ratio"].Exact)
+	}
+	// 转换为内置类型和调用内置函数的常量不是枚举
+	for _, name := range []string{"MaxSize", "Scale", "Label", "Cap", "Word"} {
+		if c := consts[name]; c.Type != "" || c.ElemType == constants.ElemEnum {
+			t.Fatal(name, c.Type)
+		}
+	}
+	if consts["MaxSize"].Value != 1024 {
+		t.Fatal(consts["MaxSize"].Value)
+	}
+	if c := consts["Letter"]; c.Value != "A" || c.Exact != `"A"` {
+		t.Fatal(c.Value, c.Exact)
+	}
+	if consts["Word"].Value != nil {
+		t.Fatal(consts["Word"].Value)
+	}
+	if consts["Remote"].Value != nil {
+		t.Fatal(consts["Remote"].Value)
+	}
+}
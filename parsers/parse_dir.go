@@ -3,6 +3,7 @@ package parsers
 import (
 	"github.com/linxlib/astp/constants"
 	"github.com/linxlib/astp/types"
+	"go/ast"
 	"os"
 	"path/filepath"
 )
@@ -19,108 +20,160 @@ func parseDir(dir string, proj *types.Project) map[string]*types.File {
 		}
 	}
 	// 分析完这个目录后, 进行其中类型标记为this的处理
+	handleThisConst(files, proj)
+	// 先处理类型别名, 使后续引用到别名的字段能拿到完整的结构
+	for _, file := range files {
+		for _, s := range file.Struct {
+			handleStructThisUnderlying(files, s)
+		}
+	}
 	for _, file := range files {
 		for _, s := range file.Struct {
 			// 处理结构中的字段
 			handleStructThisField(files, s)
 			// 处理结构中的方法(参数和返回值)
 			handleStructThisMethod(files, s)
+			// 处理泛型约束中引用的同包接口
+			handleStructThisConstraint(files, s)
+		}
+		// 处理函数(参数和返回值)
+		for _, f := range file.Function {
+			handleThisFunction(files, f)
+		}
+		// 处理变量的类型
+		for _, v := range file.Variable {
+			handleThisVariable(files, v)
 		}
 	}
 
 	return files
 }
 
+// handleThisConst 按包对常量求值, 常量可以引用同包其他文件中的常量(值和类型)
+func handleThisConst(filesCopy map[string]*types.File, proj *types.Project) {
+	consts := make([]*types.Const, 0)
+	// 类型转换中的类型按常量所在文件的导入解析
+	files := make(map[*types.Const]*types.File)
+	for _, file := range filesCopy {
+		for _, c := range file.Const {
+			files[c] = file
+		}
+		consts = append(consts, file.Const...)
+	}
+	evalConsts(consts, func(c *types.Const, typ ast.Expr) *types.Const {
+		file := files[c]
+		return resolveConstType(typ, file.Package, file.Import, proj)
+	})
+}
+
+// handleThisVariable 处理类型为同包结构的变量
+func handleThisVariable(filesCopy map[string]*types.File, v *types.Variable) {
+	if v.Type == "" || v.Package == nil || v.TypePkgPath != v.Package.Path {
+		return
+	}
+	for _, f := range filesCopy {
+		for _, s2 := range f.Struct {
+			if s2.Name == v.Type {
+				v.Struct = s2.Clone()
+				return
+			}
+		}
+	}
+}
+
 func handleStructThisMethod(filesCopy map[string]*types.File, s *types.Struct) {
 	for _, method := range s.Method {
-		// 针对方法的参数
-		for _, param := range method.Param {
-			if param.Package != nil && param.Package.Type == constants.PackageSamePackage {
-				for _, f := range filesCopy {
-					for _, s2 := range f.Struct {
-						if s2.Name == param.Type {
-							if !s2.Top {
-								handleStructThisField(filesCopy, s2)
-							}
-							param.Struct = s2.Clone()
-							param.Package = s2.Package.Clone()
-							break
+		handleThisFunction(filesCopy, method)
+	}
+}
+
+// handleThisFunction 处理方法/函数的参数和返回值中类型标记为this的部分
+func handleThisFunction(filesCopy map[string]*types.File, method *types.Function) {
+	// 针对方法的参数
+	for _, param := range method.Param {
+		if param.Package != nil && param.Package.Type == constants.PackageSamePackage {
+			for _, f := range filesCopy {
+				for _, s2 := range f.Struct {
+					if s2.Name == param.Type {
+						if !s2.Top {
+							handleStructThisField(filesCopy, s2)
 						}
+						param.Struct = s2.Clone()
+						param.Package = s2.Package.Clone()
+						break
 					}
-
 				}
+
 			}
-			if param.Generic {
-				for _, tp := range param.TypeParam {
-					if tp.Package != nil && tp.Package.Type == constants.PackageSamePackage {
-						for _, f := range filesCopy {
-							for _, s2 := range f.Struct {
-								if s2.Name == tp.Type {
-									if !s2.Top {
-										handleStructThisField(filesCopy, s2)
-									}
-									tp.Struct = s2.Clone()
-									tp.Package = s2.Package.Clone()
-									break
+		}
+		if param.Generic {
+			for _, tp := range param.TypeParam {
+				if tp.Package != nil && tp.Package.Type == constants.PackageSamePackage {
+					for _, f := range filesCopy {
+						for _, s2 := range f.Struct {
+							if s2.Name == tp.Type {
+								if !s2.Top {
+									handleStructThisField(filesCopy, s2)
 								}
+								tp.Struct = s2.Clone()
+								tp.Package = s2.Package.Clone()
+								break
 							}
-
 						}
+
 					}
 				}
 			}
-
 		}
 
-		// 针对方法的返回值
-		for _, result := range method.Result {
-			if result.Package != nil && result.Package.Type == constants.PackageSamePackage {
-				for _, f := range filesCopy {
-					for _, s2 := range f.Struct {
-						if s2.Name == result.Type {
-							if !s2.Top {
-								handleStructThisField(filesCopy, s2)
-							}
-							result.Struct = s2.Clone()
-							result.Package = s2.Package.Clone()
+	}
+
+	// 针对方法的返回值
+	for _, result := range method.Result {
+		if result.Package != nil && result.Package.Type == constants.PackageSamePackage {
+			for _, f := range filesCopy {
+				for _, s2 := range f.Struct {
+					if s2.Name == result.Type {
+						if !s2.Top {
+							handleStructThisField(filesCopy, s2)
 						}
+						result.Struct = s2.Clone()
+						result.Package = s2.Package.Clone()
 					}
-
 				}
-			}
-			if result.Generic {
-				for _, tp := range result.TypeParam {
-					if tp.Package != nil && tp.Package.Type == constants.PackageSamePackage {
-						for _, f := range filesCopy {
-							for _, s2 := range f.Struct {
-								if s2.Name == tp.Type {
-									if !s2.Top {
-										handleStructThisField(filesCopy, s2)
-									}
-									tp.Struct = s2.Clone()
-									tp.Package = s2.Package.Clone()
 
+			}
+		}
+		if result.Generic {
+			for _, tp := range result.TypeParam {
+				if tp.Package != nil && tp.Package.Type == constants.PackageSamePackage {
+					for _, f := range filesCopy {
+						for _, s2 := range f.Struct {
+							if s2.Name == tp.Type {
+								if !s2.Top {
+									handleStructThisField(filesCopy, s2)
 								}
-							}
+								tp.Struct = s2.Clone()
+								tp.Package = s2.Package.Clone()
 
+							}
 						}
+
 					}
 				}
-				// 还要处理result的
-				if result.Struct != nil {
-					for _, field := range result.Struct.Field {
-						if field.Generic {
-							field.Package = new(types.Package)
-							field.Package.Type = constants.PackageSamePackage
-						}
+			}
+			// 还要处理result的
+			if result.Struct != nil {
+				for _, field := range result.Struct.Field {
+					if field.Generic {
+						field.Package = new(types.Package)
+						field.Package.Type = constants.PackageSamePackage
 					}
 				}
-
 			}
-		}
 
+		}
 	}
-
 }
 
 func handleStructThisField(filesCopy map[string]*types.File, s *types.Struct) {
@@ -128,13 +181,20 @@ func handleStructThisField(filesCopy map[string]*types.File, s *types.Struct) {
 		if field.Package != nil && field.Package.Type == constants.PackageSamePackage {
 			for _, f := range filesCopy {
 				for _, s2 := range f.Struct {
-					if s2.Name == field.Type {
+					if s2.Name != field.Type {
+						continue
+					}
+					if s2 == s || reachStruct(filesCopy, s2, s.Name, make(map[string]bool)) {
+						// 自引用或相互引用的结构 eg. Node{ Children []*Node }, 只记录引用, 不再展开
+						field.Recursive = true
+						field.Struct = s2.Ref()
+					} else {
 						if !s2.Top {
 							handleStructThisField(filesCopy, s2)
 						}
 						field.Struct = s2.Clone()
-						field.Package = s2.Package.Clone()
 					}
+					field.Package = s2.Package.Clone()
 				}
 			}
 		}
@@ -149,3 +209,50 @@ func handleStructThisField(filesCopy map[string]*types.File, s *types.Struct) {
 	}
 
 }
+
+// reachStruct 结构 s 能否经由同包类型的字段(逐层)引用到名为 target 的结构
+func reachStruct(filesCopy map[string]*types.File, s *types.Struct, target string, visiting map[string]bool) bool {
+	visiting[s.Name] = true
+	for _, field := range s.Field {
+		// 已处理过的字段其包类型不再是this, 按包路径判断
+		if field.Package == nil || (field.Package.Type != constants.PackageSamePackage && field.Package.Path != s.Package.Path) {
+			continue
+		}
+		if field.Type == target {
+			return true
+		}
+		if visiting[field.Type] {
+			continue
+		}
+		for _, f := range filesCopy {
+			for _, s2 := range f.Struct {
+				if s2.Name == field.Type && reachStruct(filesCopy, s2, target, visiting) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func handleStructThisConstraint(filesCopy map[string]*types.File, s *types.Struct) {
+	for _, tp := range s.TypeParam {
+		if tp.Constraint == nil {
+			continue
+		}
+		for _, term := range tp.Constraint.Ref {
+			if term.Package == nil || term.Package.Type != constants.PackageSamePackage {
+				continue
+			}
+			for _, f := range filesCopy {
+				for _, s2 := range f.Struct {
+					if s2.Name == term.Type {
+						term.Struct = s2.Clone()
+						term.Package = s2.Package.Clone()
+						term.Package.Type = constants.PackageSamePackage
+					}
+				}
+			}
+		}
+	}
+}
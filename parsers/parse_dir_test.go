@@ -0,0 +1,35 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/types"
+	"path/filepath"
+	"testing"
+)
+
+func Test_parseDirRecursive(t *testing.T) {
+	base, _ := filepath.Abs("./tests")
+	proj := &types.Project{
+		BaseDir: base,
+		ModPkg:  "tests",
+	}
+	files := parseDir(filepath.Join(base, "recursive"), proj)
+	structs := make(map[string]*types.Struct)
+	for _, f := range files {
+		for _, s := range f.Struct {
+			structs[s.Name] = s
+		}
+	}
+	children := structs["Node"].Field[2]
+	if !children.Recursive || children.Struct == nil || children.Struct.Field != nil {
+		t.Fatalf("recursive: %v, struct: %v", children.Recursive, children.Struct)
+	}
+	a, b := structs["A"].Field[0], structs["B"].Field[0]
+	if !a.Recursive || !b.Recursive || a.Struct.Name != "B" || b.Struct.Name != "A" {
+		t.Fatalf("A: %v %v, B: %v %v", a.Recursive, a.Struct, b.Recursive, b.Struct)
+	}
+	// 不在环上的字段正常展开
+	leaf := structs["B"].Field[1]
+	if leaf.Recursive || leaf.Struct == nil || len(leaf.Struct.Field) != 1 {
+		t.Fatalf("recursive: %v, struct: %v", leaf.Recursive, leaf.Struct)
+	}
+}
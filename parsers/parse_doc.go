@@ -9,10 +9,7 @@ import (
 func parseDoc(cg *ast.CommentGroup, selfName string) []*types.Comment {
 	var result = make([]*types.Comment, 0)
 	if cg != nil && cg.List != nil {
-		comments := internal.GetComments(cg)
-		for i, comment := range comments {
-			result = append(result, types.OfComment(i, comment, selfName))
-		}
+		result = append(result, types.OfComments(internal.GetRawComments(cg), selfName)...)
 	}
 	return result
 }
@@ -21,10 +18,7 @@ func parseDocs(cgs []*ast.CommentGroup, name string) []*types.Comment {
 	var result = make([]*types.Comment, 0)
 	for _, cg := range cgs {
 		if cg != nil && cg.List != nil {
-			comments := internal.GetComments(cg)
-			for i, comment := range comments {
-				result = append(result, types.OfComment(i, comment, "Package "+name))
-			}
+			result = append(result, types.OfComments(internal.GetRawComments(cg), "Package "+name)...)
 		}
 	}
 	return result
@@ -1,7 +1,9 @@
 package parsers
 
 import (
+	"github.com/linxlib/astp/constants"
 	"github.com/linxlib/astp/types"
+	"go/ast"
 	"go/parser"
 	"go/token"
 	"testing"
@@ -26,3 +28,25 @@ func Test_parseDoc(t *testing.T) {
 	}
 
 }
+
+func Test_parseDocGofmt(t *testing.T) {
+	// 经过 gofmt 格式化的注释: 缩进的行变为空行加上以 tab 缩进的代码块
+	node, _ := parser.ParseFile(token.NewFileSet(), "./tests/for_doc.go", nil, parser.ParseComments)
+	fn := node.Decls[0].(*ast.FuncDecl)
+	list := parseDoc(fn.Doc, "GetUser")
+	if len(list) != 5 || !list[0].IsSelf {
+		t.Fatal(list)
+	}
+	if s := list[1]; s.CustomAttr != "SUMMARY" || s.AttrValue != "第一行\n第二行\n第三行" {
+		t.Fatal(s.AttrValue)
+	}
+	if d := list[2]; d.AttrValue != "很长的 继续" {
+		t.Fatal(d.AttrValue)
+	}
+	if e := list[3]; e.Lang != "json" || e.Block != "{\n  \"id\": 1,\n  \"name\": \"a\"\n}" || e.InlineValue() != "json" {
+		t.Fatal(e.Block)
+	}
+	if g := list[4]; g.AttrType != constants.AT_GET || g.AttrValue != "/users" {
+		t.Fatal(g.AttrValue)
+	}
+}
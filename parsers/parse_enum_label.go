@@ -0,0 +1,151 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// enumLabelMethods 可决定枚举文本表示的方法
+var enumLabelMethods = map[string]bool{
+	"String":      true,
+	"MarshalText": true,
+	"MarshalJSON": true,
+}
+
+// parseEnumLabel 解析文件中可静态得出的枚举项显示名称
+// 1. 枚举类型的 String/MarshalText/MarshalJSON 方法中 switch 的各分支直接返回的字符串
+// 2. 包级别的 map[T]string 字面量 eg. var statusNames = map[Status]string{Paid: "paid"}
+func parseEnumLabel(af *ast.File) []*types.EnumLabel {
+	result := make([]*types.EnumLabel, 0)
+	for _, decl := range af.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 || decl.Body == nil || !enumLabelMethods[decl.Name.Name] {
+				continue
+			}
+			typ := receiverTypeName(decl.Recv.List[0].Type)
+			if typ == "" {
+				continue
+			}
+			// 记录类型实现了该方法
+			result = append(result, &types.EnumLabel{Type: typ, Source: decl.Name.Name})
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				sw, ok := n.(*ast.SwitchStmt)
+				if !ok {
+					return true
+				}
+				for _, stmt := range sw.Body.List {
+					clause := stmt.(*ast.CaseClause)
+					label, ok := returnedString(clause.Body)
+					if !ok {
+						continue
+					}
+					for _, expr := range clause.List {
+						if name := constName(expr); name != "" {
+							result = append(result, &types.EnumLabel{Type: typ, Name: name, Label: label, Source: decl.Name.Name})
+						}
+					}
+				}
+				return false
+			})
+		case *ast.GenDecl:
+			if decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for i, value := range spec.Values {
+					lit, ok := value.(*ast.CompositeLit)
+					if !ok || i >= len(spec.Names) {
+						continue
+					}
+					mt, ok := lit.Type.(*ast.MapType)
+					if !ok {
+						continue
+					}
+					if v, ok := mt.Value.(*ast.Ident); !ok || v.Name != "string" {
+						continue
+					}
+					typ := receiverTypeName(mt.Key)
+					for _, elt := range lit.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						label, ok := stringLit(kv.Value)
+						name := constName(kv.Key)
+						if !ok || name == "" || typ == "" {
+							continue
+						}
+						result = append(result, &types.EnumLabel{Type: typ, Name: name, Label: label, Source: spec.Names[i].Name})
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// receiverTypeName 接收器/map键的类型名 eg. Status / *Status / order.Status -> Status
+func receiverTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	}
+	return ""
+}
+
+// constName case 或 map 键中引用的常量名 eg. Paid / order.Paid
+func constName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	}
+	return ""
+}
+
+// returnedString 分支中直接返回的字符串
+// 支持 return "paid" / return []byte("paid"), nil / return []byte(`"paid"`), nil (json字符串会去掉引号)
+func returnedString(stmts []ast.Stmt) (string, bool) {
+	for _, stmt := range stmts {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			continue
+		}
+		expr := ret.Results[0]
+		if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if _, ok := call.Fun.(*ast.ArrayType); ok {
+				expr = call.Args[0]
+			}
+		}
+		s, ok := stringLit(expr)
+		if !ok {
+			return "", false
+		}
+		if unquoted, err := strconv.Unquote(s); err == nil && len(s) > 1 && s[0] == '"' {
+			s = unquoted
+		}
+		return s, true
+	}
+	return "", false
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return s, true
+}
@@ -0,0 +1,35 @@
+package parsers
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseEnumLabel(t *testing.T) {
+	node, _ := parser.ParseFile(token.NewFileSet(), "./tests/for_enum_label.txt", nil, parser.ParseComments)
+	got := make(map[string]string)
+	for _, label := range parseEnumLabel(node) {
+		if label.Type != "Status" {
+			t.Fatal(label.Type)
+		}
+		got[label.Source+"."+label.Name] = label.Label
+	}
+	want := map[string]string{
+		"statusNames.Created": "created",
+		"statusNames.Closed":  "closed",
+		"String.":             "",
+		"String.Created":      "open",
+		"String.Paid":         "open",
+		"MarshalJSON.":        "",
+		"MarshalJSON.Paid":    "PAID",
+	}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatal(k, got[k])
+		}
+	}
+}
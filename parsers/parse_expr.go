@@ -0,0 +1,169 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	gotypes "go/types"
+)
+
+// parseExpr 将表达式解析为结构化的形式(用于变量的初始化表达式)
+// 复合字面量记录其类型和各元素(含键), 函数调用记录被调用的函数和参数, 标识符/选择器记录引用的名称和包
+func parseExpr(expr ast.Expr, imports []*types.Import) *types.Expr {
+	e := &types.Expr{
+		Kind: constants.ExprOther,
+		Text: gotypes.ExprString(expr),
+	}
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return parseExpr(expr.X, imports)
+	case *ast.BasicLit:
+		e.Kind = constants.ExprLit
+		e.Value = constValue(constant.MakeFromLiteral(expr.Value, expr.Kind, 0))
+	case *ast.Ident:
+		e.Kind = constants.ExprIdent
+		e.Name = expr.Name
+	case *ast.SelectorExpr:
+		e.Kind = constants.ExprSelector
+		e.Name = expr.Sel.Name
+		e.Package = exprPackage(expr.X, imports)
+	case *ast.CompositeLit:
+		e.Kind = constants.ExprComposite
+		if expr.Type != nil {
+			e.TypeName = gotypes.ExprString(expr.Type)
+			e.Type, e.Package = exprTypeName(expr.Type, imports)
+		}
+		for _, elt := range expr.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				child := parseExpr(kv.Value, imports)
+				child.Key = exprKey(kv.Key)
+				e.Elts = append(e.Elts, child)
+				continue
+			}
+			e.Elts = append(e.Elts, parseExpr(elt, imports))
+		}
+	case *ast.CallExpr:
+		e.Kind = constants.ExprCall
+		switch fun := expr.Fun.(type) {
+		case *ast.Ident:
+			e.Name = fun.Name
+		case *ast.SelectorExpr:
+			e.Name = fun.Sel.Name
+			e.Package = exprPackage(fun.X, imports)
+		default:
+			e.Name = gotypes.ExprString(fun)
+		}
+		for _, arg := range expr.Args {
+			e.Args = append(e.Args, parseExpr(arg, imports))
+		}
+	case *ast.UnaryExpr:
+		e.Kind = constants.ExprUnary
+		e.Op = expr.Op.String()
+		e.Args = []*types.Expr{parseExpr(expr.X, imports)}
+	case *ast.BinaryExpr:
+		e.Kind = constants.ExprBinary
+		e.Op = expr.Op.String()
+		e.Args = []*types.Expr{parseExpr(expr.X, imports), parseExpr(expr.Y, imports)}
+	case *ast.FuncLit:
+		e.Kind = constants.ExprFunc
+	}
+	return e
+}
+
+// exprPackage 选择器 pkg.Name 中 pkg 对应的导入包, 不是导入包时(eg. 结构体字段 cfg.Port)返回nil
+func exprPackage(x ast.Expr, imports []*types.Import) *types.Package {
+	ident, ok := x.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	for _, i := range imports {
+		if i.Name == ident.Name || i.Alias == ident.Name {
+			return &types.Package{
+				Name: ident.Name,
+				Path: i.Path,
+			}
+		}
+	}
+	return nil
+}
+
+// exprTypeName 复合字面量的基础类型名 eg. []*config.Item -> Item, config
+func exprTypeName(expr ast.Expr, imports []*types.Import) (string, *types.Package) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name, nil
+	case *ast.SelectorExpr:
+		return expr.Sel.Name, exprPackage(expr.X, imports)
+	case *ast.StarExpr:
+		return exprTypeName(expr.X, imports)
+	case *ast.ArrayType:
+		return exprTypeName(expr.Elt, imports)
+	case *ast.IndexExpr:
+		return exprTypeName(expr.X, imports)
+	case *ast.IndexListExpr:
+		return exprTypeName(expr.X, imports)
+	case *ast.MapType:
+		return "map", nil
+	}
+	return "", nil
+}
+
+// exprKey 复合字面量元素的键, 字段名/字符串键取其值, 其他取源码
+func exprKey(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.BasicLit:
+		if s, ok := stringLit(expr); ok {
+			return s
+		}
+		return expr.Value
+	}
+	return gotypes.ExprString(expr)
+}
+
+// inferVarType 未声明类型的变量, 由初始化表达式推断其类型
+// 仅推断可静态得出的情况: 字面量, 复合字面量(及其地址), bool, 常见的 error 构造函数
+func inferVarType(expr ast.Expr) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return inferVarType(expr.X)
+	case *ast.BasicLit:
+		switch expr.Kind {
+		case token.INT:
+			return ast.NewIdent("int")
+		case token.FLOAT:
+			return ast.NewIdent("float64")
+		case token.IMAG:
+			return ast.NewIdent("complex128")
+		case token.CHAR:
+			return ast.NewIdent("rune")
+		case token.STRING:
+			return ast.NewIdent("string")
+		}
+	case *ast.Ident:
+		if expr.Name == "true" || expr.Name == "false" {
+			return ast.NewIdent("bool")
+		}
+	case *ast.CompositeLit:
+		return expr.Type
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			if t := inferVarType(expr.X); t != nil {
+				return &ast.StarExpr{X: t}
+			}
+		}
+	case *ast.CallExpr:
+		if sel, ok := expr.Fun.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				switch x.Name + "." + sel.Sel.Name {
+				case "errors.New", "fmt.Errorf":
+					return ast.NewIdent("error")
+				}
+			}
+		}
+	}
+	return nil
+}
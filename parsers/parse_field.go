@@ -6,9 +6,10 @@ import (
 	"github.com/linxlib/astp/types"
 
 	"go/ast"
+	"go/token"
 )
 
-func parseField(fields []*ast.Field, structTypeParams []*types.TypeParam, imports []*types.Import, proj *types.Project) []*types.Field {
+func parseField(fields []*ast.Field, fset *token.FileSet, structTypeParams []*types.TypeParam, imports []*types.Import, proj *types.Project) []*types.Field {
 	var sf = make([]*types.Field, 0)
 	for idx, field := range fields {
 		af1 := new(types.Field)
@@ -25,18 +26,24 @@ func parseField(fields []*ast.Field, structTypeParams []*types.TypeParam, import
 		}
 		af1.Comment = parseDoc(field.Comment, af1.Name)
 		af1.Doc = parseDoc(field.Doc, af1.Name)
+		af1.Pos = position(fset, field.Pos(), proj.BaseDir)
 		if field.Tag != nil {
 			af1.Tag = field.Tag.Value
+			// 标签语法错误在 checkTags 中报告
+			af1.Tags, _ = types.ParseTag(af1.Tag)
+			af1.Rules = types.ParseRules(af1.ValidateTag())
 		}
 
 		// 对于某个字段, 查找其类型的包.
 		// 包含该类型结构的包, 类型中泛型类型所在的包等等
-		info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
+		info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(structTypeParams)
 		findPackageV2(field.Type, info)
 		if info.Valid {
 			af1.Type = info.Name
 			af1.Slice = info.Slice
 			af1.Pointer = info.Pointer
+			af1.Chan = info.Chan
+			af1.ChanDir = info.ChanDir
 			af1.Generic = info.Generic
 			af1.Struct = findType(info.PkgPath, info.Name, proj.BaseDir, proj.ModPkg, proj)
 			if af1.Struct != nil {
@@ -64,6 +71,7 @@ func parseField(fields []*ast.Field, structTypeParams []*types.TypeParam, import
 						tp.Package.Path = child.PkgPath
 						tp.Package.Name = child.PkgName
 						tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj)
+						tp.TypeParam = parseTypeArgs(child, proj)
 						if len(structTypeParams) > 0 {
 							for _, tp1 := range structTypeParams {
 								if tp1.Type == info.Name {
@@ -114,10 +122,6 @@ func parseField(fields []*ast.Field, structTypeParams []*types.TypeParam, import
 
 		}
 
-		if field.Tag != nil {
-			af1.Tag = field.Tag.Value
-		}
-
 		sf = append(sf, af1)
 	}
 	return sf
@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"fmt"
 	"github.com/linxlib/astp/internal"
 	"github.com/linxlib/astp/types"
 	"go/parser"
@@ -11,19 +12,22 @@ import (
 
 func ParseFile(file string, proj *types.Project) *types.File {
 	name := filepath.Base(file)
-	node, _ := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, file, nil, parser.ParseComments)
 	p := parsePackage(node, file, proj)
 
 	doc := parseDocs(node.Comments, p.Name)
 	i := parseImport(node)
-	v := parseVar(node, proj, i)
+	v := parseVar(node, p, proj, i)
 
-	v1 := parseConst(node, p)
+	v1 := parseConst(node, p, i, proj)
+	el := parseEnumLabel(node)
 
-	//f1 := parseFunction(node, p, i, proj)
+	f1 := parseFunction(node, fset, p, i, proj)
+	ins := parseInstance(node, fset, p, i, proj)
 	//f2 := parseInterface(node,  i, proj)
 
-	s := parseStruct(node, p, i, proj)
+	s := parseStruct(node, fset, p, i, proj)
 	f := &types.File{
 		Key:       internal.GetKey(p.Path, name),
 		KeyHash:   internal.GetKeyHash(p.Path, name),
@@ -33,12 +37,18 @@ func ParseFile(file string, proj *types.Project) *types.File {
 		Import:    i,
 		Variable:  v,
 		Const:     v1,
-		Function:  nil,
+		Function:  f1,
 		Interface: nil,
 		Struct:    s,
+		Instance:  ins,
+		EnumLabel: el,
+		// 检查结构体标签的语法
+		Diagnostic: checkTags(s),
 	}
 	proj.AddFile(f)
 	if f.IsMainPackage() {
+		// main 包只解析了这一个文件, 不经过 parseDir, 在这里对其常量求值
+		handleThisConst(map[string]*types.File{f.KeyHash: f}, proj)
 		for _, i1 := range f.Import {
 			if strings.HasPrefix(i1.Path, f.Package.Path) {
 				dir := getPackageDir(i1.Path, proj.BaseDir, proj.ModPkg)
@@ -50,3 +60,17 @@ func ParseFile(file string, proj *types.Project) *types.File {
 	}
 	return f
 }
+
+// position 返回节点在文件中的位置, 文件为相对于 baseDir 的路径 eg. controllers/user.go:12
+// 不在 baseDir 中的文件(eg. 依赖的模块)使用完整路径
+func position(fset *token.FileSet, pos token.Pos, baseDir string) string {
+	if fset == nil || !pos.IsValid() {
+		return ""
+	}
+	p := fset.Position(pos)
+	name := p.Filename
+	if rel, err := filepath.Rel(baseDir, name); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		name = rel
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(name), p.Line)
+}
@@ -5,9 +5,10 @@ import (
 	"github.com/linxlib/astp/internal"
 	"github.com/linxlib/astp/types"
 	"go/ast"
+	"go/token"
 )
 
-func parseFunction(af *ast.File, p *types.Package, imports []*types.Import, proj *types.Project) []*types.Function {
+func parseFunction(af *ast.File, fset *token.FileSet, p *types.Package, imports []*types.Import, proj *types.Project) []*types.Function {
 	methods := make([]*types.Function, 0)
 	funcIndex := 0
 	for _, decl := range af.Decls {
@@ -24,6 +25,7 @@ func parseFunction(af *ast.File, p *types.Package, imports []*types.Import, proj
 					Private: internal.IsPrivate(decl.Name.Name),
 					Index:   funcIndex,
 					Package: p.Clone(),
+					Pos:     position(fset, decl.Pos(), proj.BaseDir),
 				}
 				funcIndex++
 
@@ -31,8 +33,10 @@ func parseFunction(af *ast.File, p *types.Package, imports []*types.Import, proj
 					method.Generic = true
 					method.TypeParam = parseTypeParamV2(decl.Type.TypeParams, imports, proj)
 				}
-				method.Param = parseParam(decl.Type.Params, []*types.TypeParam{}, imports, proj)
-				method.Result = parseResults(decl.Type.Results, []*types.TypeParam{}, imports, proj)
+				method.Param = parseParam(decl.Type.Params, fset, method.TypeParam, imports, proj)
+				method.Result = parseResults(decl.Type.Results, method.TypeParam, imports, proj)
+				method.Variadic = method.IsVariadic()
+				handleParamAttrs(method)
 				methods = append(methods, method)
 			}
 
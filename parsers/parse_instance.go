@@ -0,0 +1,132 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+)
+
+// parseInstance 查找文件中对泛型函数的显式实例化调用 eg. Paginate[User](q) / pkg.Paginate[User, int](q)
+// 此时还无法确定被调用的是否为泛型函数(也可能是函数切片的下标调用等), 交由 Project 与已解析的泛型函数进行匹配
+// 函数体和包级变量的初始化表达式(eg. var p = Paginate[User](q))中的调用都会被查找
+func parseInstance(af *ast.File, fset *token.FileSet, p *types.Package, imports []*types.Import, proj *types.Project) []*types.FuncInstance {
+	result := make([]*types.FuncInstance, 0)
+	for _, decl := range af.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Body == nil {
+				continue
+			}
+			// 函数/接收器中声明的泛型参数, 使用它们的调用不是具体的实例化
+			scope := make([]*types.TypeParam, 0)
+			if decl.Type.TypeParams != nil {
+				for _, field := range decl.Type.TypeParams.List {
+					for _, name := range field.Names {
+						scope = append(scope, &types.TypeParam{Type: name.Name})
+					}
+				}
+			}
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				scope = append(scope, receiverTypeParams(decl.Recv.List[0].Type)...)
+			}
+			result = append(result, findInstances(decl.Body, scope, fset, p, imports, proj)...)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.ValueSpec); ok {
+					for _, value := range spec.Values {
+						result = append(result, findInstances(value, nil, fset, p, imports, proj)...)
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// findInstances 查找节点中的显式实例化调用, scope 为当前作用域中的泛型参数
+func findInstances(root ast.Node, scope []*types.TypeParam, fset *token.FileSet, p *types.Package, imports []*types.Import, proj *types.Project) []*types.FuncInstance {
+	result := make([]*types.FuncInstance, 0)
+	ast.Inspect(root, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		var fun ast.Expr
+		var indices []ast.Expr
+		switch spec := call.Fun.(type) {
+		case *ast.IndexExpr:
+			fun = spec.X
+			indices = []ast.Expr{spec.Index}
+		case *ast.IndexListExpr:
+			fun = spec.X
+			indices = spec.Indices
+		default:
+			return true
+		}
+		inst := &types.FuncInstance{
+			TypeName: gotypes.ExprString(call.Fun),
+			Package:  new(types.Package),
+			Caller:   p.Clone(),
+		}
+		switch spec := fun.(type) {
+		case *ast.Ident:
+			inst.Name = spec.Name
+			inst.Package.Name = p.Name
+			inst.Package.Path = p.Path
+			inst.Package.Type = constants.PackageSamePackage
+		case *ast.SelectorExpr:
+			x, ok := spec.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			imported := false
+			for _, i := range imports {
+				if i.Alias == x.Name {
+					inst.Package.Name = i.Name
+					inst.Package.Path = i.Path
+					inst.Package.Type = checkPackage(proj.ModPkg, i.Path)
+					imported = true
+					break
+				}
+			}
+			if !imported {
+				return true
+			}
+			if inst.Package.Type == constants.PackageOtherPackage {
+				parsePackageOnce(inst.Package.Path, proj)
+			}
+			inst.Name = spec.Sel.Name
+		default:
+			return true
+		}
+		info := types.NewTypePkgInfo(proj.ModPkg, p.Path, imports).WithTypeParams(scope)
+		for _, index := range indices {
+			child := info.NewChild()
+			findPackageV2(index, child)
+			if !child.Valid || hasTypeParam(child) {
+				return true
+			}
+			info.Children = append(info.Children, child)
+		}
+		inst.TypeParam = parseTypeArgs(info, proj)
+		inst.Pos = position(fset, call.Pos(), proj.BaseDir)
+		result = append(result, inst)
+		return true
+	})
+	return result
+}
+
+// hasTypeParam 类型中是否引用了作用域中的泛型参数
+func hasTypeParam(info *types.TypePkgInfo) bool {
+	if info.IsTypeParam(info.Name) && info.PkgName == "" {
+		return true
+	}
+	for _, child := range info.Children {
+		if hasTypeParam(child) {
+			return true
+		}
+	}
+	return false
+}
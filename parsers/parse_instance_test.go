@@ -0,0 +1,40 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseInstance(t *testing.T) {
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, "./tests/for_instance.txt", nil, parser.ParseComments)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	pkg := parsePackage(node, "./tests/for_package.go", proj)
+	list := parseInstance(node, fset, pkg, parseImport(node), proj)
+	// Paginate[T] 使用了泛型参数, handlers[0](1) 不是实例化
+	if len(list) != 3 {
+		t.Fatal(len(list))
+	}
+	if list[0].Name != "Paginate" || list[0].TypeName != "Paginate[types.Struct]" {
+		t.Fatalf("name: %s, type name: %s", list[0].Name, list[0].TypeName)
+	}
+	if list[0].TypeParam[0].Package.Path != "github.com/linxlib/astp/types" {
+		t.Fatalf("package: %s", list[0].TypeParam[0].Package.Path)
+	}
+	if list[0].Pos != "for_instance.txt:16" {
+		t.Fatal(list[0].Pos)
+	}
+	if list[1].Package.Type != constants.PackageSamePackage || !list[1].TypeParam[0].Pointer || list[1].TypeParam[0].Type != "User" {
+		t.Fatalf("package type: %v, type param: %v", list[1].Package.Type, list[1].TypeParam)
+	}
+	// 包级变量的初始化表达式
+	if list[2].TypeName != "Paginate[User]" || list[2].Pos != "for_instance.txt:21" {
+		t.Fatal(list[2].TypeName, list[2].Pos)
+	}
+}
@@ -5,9 +5,10 @@ import (
 	"github.com/linxlib/astp/internal"
 	"github.com/linxlib/astp/types"
 	"go/ast"
+	"go/token"
 )
 
-func parseMethod(af *ast.File, s *types.Struct, imports []*types.Import, proj *types.Project) []*types.Function {
+func parseMethod(af *ast.File, fset *token.FileSet, s *types.Struct, imports []*types.Import, proj *types.Project) []*types.Function {
 	methods := make([]*types.Function, 0)
 	methodIndex := 0
 	for _, decl := range af.Decls {
@@ -28,6 +29,7 @@ func parseMethod(af *ast.File, s *types.Struct, imports []*types.Import, proj *t
 					ElemType: constants.ElemFunc,
 					TypeName: decl.Name.Name,
 					Private:  internal.IsPrivate(decl.Name.Name),
+					Pos:      position(fset, decl.Pos(), proj.BaseDir),
 				}
 				// 无需解析私有方法和非 @标记的方法
 				if method.Private || !method.IsOp() {
@@ -35,8 +37,10 @@ func parseMethod(af *ast.File, s *types.Struct, imports []*types.Import, proj *t
 				}
 				method.Receiver = recv
 
-				method.Param = parseParam(decl.Type.Params, recv.TypeParam, imports, proj)
+				method.Param = parseParam(decl.Type.Params, fset, recv.TypeParam, imports, proj)
 				method.Result = parseResults(decl.Type.Results, recv.TypeParam, imports, proj)
+				method.Variadic = method.IsVariadic()
+				handleParamAttrs(method)
 
 				methods = append(methods, method)
 				methodIndex++
@@ -4,9 +4,10 @@ import (
 	"github.com/linxlib/astp/constants"
 	"github.com/linxlib/astp/types"
 	"go/ast"
+	"go/token"
 )
 
-func parseParam(params *ast.FieldList, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) []*types.Param {
+func parseParam(params *ast.FieldList, fset *token.FileSet, tps []*types.TypeParam, imports []*types.Import, proj *types.Project) []*types.Param {
 	if params == nil {
 		return nil
 	}
@@ -20,13 +21,17 @@ func parseParam(params *ast.FieldList, tps []*types.TypeParam, imports []*types.
 				Name:     name.Name,
 				ElemType: constants.ElemParam,
 				Package:  new(types.Package),
+				Pos:      position(fset, name.Pos(), proj.BaseDir),
 			}
-			info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
+			info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(tps)
 			findPackageV2(param.Type, info)
 			//slog.Info(info.FullName)
 			if info.Valid {
 				par.Slice = info.Slice
+				par.Variadic = info.Variadic
 				par.Pointer = info.Pointer
+				par.Chan = info.Chan
+				par.ChanDir = info.ChanDir
 				par.Type = info.Name
 				par.Generic = info.Generic
 				par.TypeName = info.FullName
@@ -72,6 +77,7 @@ func parseParam(params *ast.FieldList, tps []*types.TypeParam, imports []*types.
 							tp.Package.Path = child.PkgPath
 							tp.Package.Name = child.PkgName
 							tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+							tp.TypeParam = parseTypeArgs(child, proj)
 
 							par.TypeParam = append(par.TypeParam, tp)
 						}
@@ -87,3 +93,12 @@ func parseParam(params *ast.FieldList, tps []*types.TypeParam, imports []*types.
 	}
 	return pars
 }
+
+// handleParamAttrs 将方法注释中 @Param(name) 的注解指定给对应的参数 eg. @Param(id) path
+func handleParamAttrs(f *types.Function) {
+	for _, param := range f.Param {
+		if attrs := types.ParamAttrs(f.Doc, param.Name); len(attrs) > 0 {
+			param.Doc = attrs
+		}
+	}
+}
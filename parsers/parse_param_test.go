@@ -0,0 +1,54 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseParamAttrs(t *testing.T) {
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, "./tests/for_param_attr.txt", nil, parser.ParseComments)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	p := &types.Package{Name: "tests", Path: "tests"}
+	imports := parseImport(node)
+
+	fn := parseFunction(node, fset, p, imports, proj)[0]
+	id, req, other := fn.Param[0], fn.Param[1], fn.Param[2]
+	if !id.HasAttr(constants.AT_PATH) || id.HasAttr(constants.AT_QUERY) || len(id.GetAttrs()) != 1 {
+		t.Fatal(id.Doc)
+	}
+	if !req.HasAttr(constants.AT_QUERY) || !req.HasCustomAttr("Deprecated") || other.Doc != nil {
+		t.Fatal(req.Doc)
+	}
+
+	s := parseStruct(node, fset, p, imports, proj)[0]
+	if !s.Field[0].HasAttr(constants.AT_IGNORE) || s.Field[1].GetAttrValue(constants.AT_DEPRECATED) != "use nickname" {
+		t.Fatal(s.Field[1].Comment)
+	}
+}
+
+func Test_parseParamAttrsEmpty(t *testing.T) {
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, "./tests/for_param_attr.txt", nil, parser.ParseComments)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	p := &types.Package{Name: "tests", Path: "tests"}
+	imports := parseImport(node)
+
+	// 单独的 @ 和没有值的 @Param(id) 不应 panic
+	fn := parseFunction(node, fset, p, imports, proj)[1]
+	if fn.Doc[1].Op || fn.Param[0].Doc != nil {
+		t.Fatal(fn.Param[0].Doc)
+	}
+	if c := fn.Doc[2]; !c.Op || c.AttrType != constants.AT_PARAM || c.Target != "id" || c.AttrValue != "" {
+		t.Fatal(c)
+	}
+}
@@ -9,7 +9,7 @@ import (
 func parseReceiver(recv *ast.FieldList, s *types.Struct, imports []*types.Import, proj *types.Project) *types.Receiver {
 	receiver := recv.List[0]
 
-	info := types.NewTypePkgInfo(proj.ModPkg, s.Package.Path, imports)
+	info := types.NewTypePkgInfo(proj.ModPkg, s.Package.Path, imports).WithTypeParams(receiverTypeParams(receiver.Type))
 	findPackageV2(receiver.Type, info)
 	if info.Name != s.Type {
 		return nil
@@ -47,9 +47,38 @@ func parseReceiver(recv *ast.FieldList, s *types.Struct, imports []*types.Import
 			tp.Package.Path = child.PkgPath
 			tp.Package.Name = child.PkgName
 			tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+			tp.TypeParam = parseTypeArgs(child, proj)
 			result.TypeParam = append(result.TypeParam, tp)
 		}
 
 	}
 	return result
 }
+
+// receiverTypeParams 接收器中声明的泛型参数 eg. func (b *BaseCtl[E, ID]) 中的 E, ID
+// 接收器中的名称可以与结构定义时不同, 因此以接收器的写法为准
+func receiverTypeParams(expr ast.Expr) []*types.TypeParam {
+	var indices []ast.Expr
+	switch spec := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeParams(spec.X)
+	case *ast.ParenExpr:
+		return receiverTypeParams(spec.X)
+	case *ast.IndexExpr:
+		indices = []ast.Expr{spec.Index}
+	case *ast.IndexListExpr:
+		indices = spec.Indices
+	}
+	result := make([]*types.TypeParam, 0, len(indices))
+	for idx, index := range indices {
+		if ident, ok := index.(*ast.Ident); ok {
+			result = append(result, &types.TypeParam{
+				Type:     ident.Name,
+				TypeName: ident.Name,
+				Index:    idx,
+				ElemType: constants.ElemGeneric,
+			})
+		}
+	}
+	return result
+}
@@ -23,12 +23,14 @@ func parseResults(params *ast.FieldList, tps []*types.TypeParam, imports []*type
 					Package:  new(types.Package),
 				}
 
-				info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
+				info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(tps)
 				findPackageV2(param.Type, info)
 				if info.Valid {
 					if info.Valid {
 						par.Slice = info.Slice
 						par.Pointer = info.Pointer
+						par.Chan = info.Chan
+						par.ChanDir = info.ChanDir
 						par.Generic = info.Generic
 						par.TypeName = info.FullName
 						if info.PkgType == constants.PackageOtherPackage {
@@ -74,6 +76,7 @@ func parseResults(params *ast.FieldList, tps []*types.TypeParam, imports []*type
 								tp.Package.Path = child.PkgPath
 								tp.Package.Name = child.PkgName
 								tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+								tp.TypeParam = parseTypeArgs(child, proj)
 
 								par.TypeParam = append(par.TypeParam, tp)
 							}
@@ -93,12 +96,14 @@ func parseResults(params *ast.FieldList, tps []*types.TypeParam, imports []*type
 				Package:  new(types.Package),
 			}
 
-			info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
+			info := types.NewTypePkgInfo(proj.ModPkg, "", imports).WithTypeParams(tps)
 			findPackageV2(param.Type, info)
 			if info.Valid {
 				if info.Valid {
 					par.Slice = info.Slice
 					par.Pointer = info.Pointer
+					par.Chan = info.Chan
+					par.ChanDir = info.ChanDir
 					par.Generic = info.Generic
 					par.Type = info.Name
 					par.TypeName = info.FullName
@@ -138,6 +143,7 @@ func parseResults(params *ast.FieldList, tps []*types.TypeParam, imports []*type
 							tp.Package.Path = child.PkgPath
 							tp.Package.Name = child.PkgName
 							tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+							tp.TypeParam = parseTypeArgs(child, proj)
 
 							for _, tp1 := range tps {
 								if par.Type == tp1.Type {
@@ -146,54 +152,16 @@ func parseResults(params *ast.FieldList, tps []*types.TypeParam, imports []*type
 								}
 							}
 
-							//children 可能还有children
+							//children 可能还有children, 已由 parseTypeArgs 逐层解析
 							if child.Children != nil {
-								for _, child1 := range child.Children {
-									tp1 := &types.TypeParam{
-										Type:          child1.Name,
-										TypeName:      child1.FullName,
-										ElemType:      constants.ElemGeneric,
-										Pointer:       child1.Pointer,
-										Slice:         child1.Slice,
-										TypeInterface: "",
-										Struct:        nil,
-										Package:       new(types.Package),
-									}
-									tp1.Package.Type = child1.PkgType
-									tp1.Package.Path = child1.PkgPath
-									tp1.Package.Name = child1.PkgName
-									tp1.Struct = findType(child1.PkgPath, child1.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
-
-									if tp.Struct != nil {
-										hasTp1 := false
-										for _, tp2 := range tps {
-											if tp2.Type == child1.Name {
-												hasTp1 = true
-												tp1.Index = tp2.Index
-												tp1.Key = tp2.Key
-												break
-											}
-										}
-										if hasTp1 {
-											tp.Struct.TypeParam = make([]*types.TypeParam, 0)
-											tp.Struct.TypeParam = append(tp.Struct.TypeParam, tp1)
-										}
-									}
-								}
 								tp.ElemType = constants.ElemStruct
 							} else {
-								var hasTp = false
 								for _, tp1 := range tps {
 									if tp1.Type == child.Name {
-										hasTp = true
 										tp.Index = tp1.Index
 										tp.Key = tp1.Key
 										break
 									}
-
-								}
-								if hasTp {
-									//tp.Index = -1
 								}
 							}
 							par.TypeParam = append(par.TypeParam, tp)
@@ -9,7 +9,7 @@ import (
 	"go/token"
 )
 
-func parseStruct(af *ast.File, p *types.Package, imports []*types.Import, proj *types.Project) []*types.Struct {
+func parseStruct(af *ast.File, fset *token.FileSet, p *types.Package, imports []*types.Import, proj *types.Project) []*types.Struct {
 	var structs []*types.Struct
 	for _, decl := range af.Decls {
 		switch decl := decl.(type) {
@@ -27,6 +27,7 @@ func parseStruct(af *ast.File, p *types.Package, imports []*types.Import, proj *
 							Package:  p.Clone(),
 							Top:      true,
 							Comment:  parseDoc(spec.Comment, spec.Name.Name),
+							Pos:      position(fset, spec.Pos(), proj.BaseDir),
 						}
 						e.TypeName = e.Package.Name + "." + e.Type
 						e.Key = internal.GetKey(p.Path, e.Name)
@@ -45,14 +46,25 @@ func parseStruct(af *ast.File, p *types.Package, imports []*types.Import, proj *
 							}
 
 						}
+						// type A = B / type Set[T comparable] = map[T]struct{}
+						e.Alias = spec.Assign.IsValid()
+						switch spec.Type.(type) {
+						case *ast.IndexExpr, *ast.IndexListExpr:
+							// type UserPage = Page[User] / type UserPage Page[User]
+							e.Underlying = parseUnderlying(spec.Type, e.TypeParam, imports, proj)
+						default:
+							if e.Alias {
+								e.Underlying = parseUnderlying(spec.Type, e.TypeParam, imports, proj)
+							}
+						}
 						switch spec1 := spec.Type.(type) {
 						case *ast.StructType:
 							{
 								// 解析字段时, 如果其中有泛型类型, 应该和上面的泛型类型一一对应, 可以生成一个唯一的key
 								// 这样方便后续使用实际类型去覆盖泛型类型时好匹配到
-								e.Field = parseField(spec1.Fields.List, e.TypeParam, imports, proj)
+								e.Field = parseField(spec1.Fields.List, fset, e.TypeParam, imports, proj)
 
-								e.Method = parseMethod(af, e, imports, proj)
+								e.Method = parseMethod(af, fset, e, imports, proj)
 
 							}
 
@@ -61,6 +73,10 @@ func parseStruct(af *ast.File, p *types.Package, imports []*types.Import, proj *
 							e.TypeName = spec1.Name
 							e.Type = spec1.Name
 						case *ast.InterfaceType:
+							// 约束接口(包含类型集合或comparable) eg. type Number interface{ ~int | ~float64 }
+							if c := parseConstraint(spec1, e.TypeParam, imports, proj); c.IsUnion() || c.Comparable {
+								e.Constraint = c
+							}
 
 						default:
 
@@ -0,0 +1,28 @@
+package parsers
+
+import (
+	"fmt"
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+)
+
+// checkTags 检查结构体字段标签的语法, 返回诊断信息
+func checkTags(structs []*types.Struct) []*types.Diagnostic {
+	result := make([]*types.Diagnostic, 0)
+	for _, s := range structs {
+		for _, f := range s.Field {
+			if f.Tag == "" {
+				continue
+			}
+			if _, err := types.ParseTag(f.Tag); err != nil {
+				result = append(result, &types.Diagnostic{
+					Level:   constants.DiagError,
+					Code:    "invalid-tag",
+					Pos:     f.Pos,
+					Message: fmt.Sprintf("%s.%s: %s", s.Name, f.Name, err),
+				})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseTag(t *testing.T) {
+	fset := token.NewFileSet()
+	node, _ := parser.ParseFile(fset, "./tests/for_tags.txt", nil, parser.ParseComments)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	structs := parseStruct(node, fset, &types.Package{Name: "tests", Path: "tests"}, parseImport(node), proj)
+	fields := make(map[string]*types.Field)
+	for _, f := range structs[0].Field {
+		fields[f.Name] = f
+	}
+
+	id := fields["ID"]
+	if id.Pos != "for_tags.txt:4" || len(id.Tags) != 2 {
+		t.Fatal(id.Pos, id.Tags)
+	}
+	if id.JSONTag().Name != "id" || !id.JSONTag().HasOption("string") || id.GormTag().Name != "user_id" || !id.GormTag().HasOption("primarykey") {
+		t.Fatal(id.Tags)
+	}
+	name := fields["Name"]
+	if !name.JSONTag().OmitEmpty() || name.FormTag().Name != "name" || name.ValidateTag().Option("max") != "32" {
+		t.Fatal(name.Tags)
+	}
+	if !fields["Token"].JSONTag().Ignored() || fields["Dash"].JSONTag().Ignored() {
+		t.Fatal(fields["Token"].Tags)
+	}
+
+	if !name.Required() || name.RuleOf("max").Kind != constants.RuleMax || name.RuleOf("max").Param != "32" {
+		t.Fatal(name.Rules)
+	}
+	if age := fields["Age"].RuleOf("gt"); age.Kind != constants.RuleMin || !age.Exclusive || age.Param != "0" {
+		t.Fatal(fields["Age"].Rules)
+	}
+	roles := fields["Roles"]
+	if len(roles.Rules) != 4 || !roles.Required() || roles.Rules[1].Kind != constants.RuleDive || roles.RuleOf("oneof") != nil {
+		t.Fatal(roles.Rules)
+	}
+	if enum := roles.Rules[2]; !enum.Elem || enum.Kind != constants.RuleEnum || len(enum.Values) != 2 || enum.Values[0] != "super admin" {
+		t.Fatal(enum.Values)
+	}
+	if or := roles.Rules[3]; or.Kind != constants.RuleOr || len(or.Or) != 2 || or.Or[0].Kind != constants.RuleFormat {
+		t.Fatal(or.Or)
+	}
+
+	diags := checkTags(structs)
+	if len(diags) != 1 || diags[0].Level != constants.DiagError || diags[0].Pos != "for_tags.txt:8" || diags[0].Message != "User.Bad: struct tag pairs not separated by spaces" {
+		t.Fatal(diags)
+	}
+}
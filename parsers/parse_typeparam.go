@@ -66,36 +66,58 @@ import (
 
 func parseTypeParamV2(list *ast.FieldList, imports []*types.Import, proj *types.Project) []*types.TypeParam {
 	result := make([]*types.TypeParam, 0)
+	groups := make([][]*types.TypeParam, 0, len(list.List))
 	idx := 0
 	for _, tp := range list.List {
+		group := make([]*types.TypeParam, 0, len(tp.Names))
 		for _, name := range tp.Names {
 			t := new(types.TypeParam)
 			t.Package = new(types.Package)
+			t.Package.Type = constants.PackageBuiltin
 			t.Index = idx
 			t.Type = name.Name
-
+			t.TypeName = name.Name
 			t.ElemType = constants.ElemGeneric
-
-			info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
-			findPackageV2(tp.Type, info)
-			if info.Valid {
-				t.Slice = info.Slice
-				t.Pointer = info.Pointer
-				t.TypeName = info.FullName
-				if info.PkgType == constants.PackageOtherPackage {
-					t.Struct = findType(info.PkgPath, info.Name, proj.BaseDir, proj.ModPkg, proj)
-					if t.Struct != nil {
-						t.Package = t.Struct.Package.Clone()
-					}
-				} else {
-					t.Package.Type = info.PkgType
-					t.Package.Path = info.PkgPath
-					t.Package.Name = info.PkgName
-				}
-			}
 			idx++
+			group = append(group, t)
 			result = append(result, t)
 		}
+		groups = append(groups, group)
+	}
+	// 约束中可以引用同一列表中的其他类型参数 eg. [S ~[]E, E any]
+	for i, tp := range list.List {
+		// 同一组类型参数共用一个约束 eg. [K, V comparable]
+		constraint := parseConstraint(tp.Type, result, imports, proj)
+		for _, t := range groups[i] {
+			t.TypeInterface = constraint.Expr
+			t.Constraint = constraint.Clone()
+		}
+	}
+	return result
+}
+
+// parseTypeArgs 解析类型实参的类型实参(可多层嵌套) eg. Resp[PageResult[[]*User]] 中的 []*User
+func parseTypeArgs(info *types.TypePkgInfo, proj *types.Project) []*types.TypeParam {
+	if info.Name == "map" || len(info.Children) == 0 {
+		return nil
+	}
+	result := make([]*types.TypeParam, 0, len(info.Children))
+	for idx, child := range info.Children {
+		tp := &types.TypeParam{
+			Type:     child.Name,
+			TypeName: child.FullName,
+			Index:    idx,
+			ElemType: constants.ElemGeneric,
+			Pointer:  child.Pointer,
+			Slice:    child.Slice,
+			Package:  new(types.Package),
+		}
+		tp.Package.Type = child.PkgType
+		tp.Package.Path = child.PkgPath
+		tp.Package.Name = child.PkgName
+		tp.Struct = findType(child.PkgPath, child.Name, proj.BaseDir, proj.ModPkg, proj).Clone()
+		tp.TypeParam = parseTypeArgs(child, proj)
+		result = append(result, tp)
 	}
 	return result
 }
@@ -7,7 +7,7 @@ import (
 	"go/token"
 )
 
-func parseVar(af *ast.File, proj *types.Project, imports []*types.Import) []*types.Variable {
+func parseVar(af *ast.File, p *types.Package, proj *types.Project, imports []*types.Import) []*types.Variable {
 	result := make([]*types.Variable, 0)
 
 	for _, decl := range af.Decls {
@@ -15,34 +15,46 @@ func parseVar(af *ast.File, proj *types.Project, imports []*types.Import) []*typ
 		case *ast.GenDecl:
 			switch decl.Tok {
 			case token.VAR:
-				for _, spec := range decl.Specs {
+				for idx, spec := range decl.Specs {
 					switch spec := spec.(type) {
 					case *ast.ValueSpec:
 						for i, v := range spec.Names {
 							vv := &types.Variable{
 								Name:     v.Name,
 								ElemType: constants.ElemVar,
+								Index:    idx,
+								Package:  p.Clone(),
+								Doc:      parseDoc(spec.Doc, v.Name),
+								Comment:  parseDoc(spec.Comment, v.Name),
 							}
+							typ := spec.Type
 							if len(spec.Values) == len(spec.Names) {
 								if a, ok := spec.Values[i].(*ast.BasicLit); ok {
 									vv.Value = a.Value
 								}
+								vv.Init = parseExpr(spec.Values[i], imports)
+								if typ == nil {
+									// 省略了类型, 由初始化表达式推断
+									typ = inferVarType(spec.Values[i])
+								}
+							}
+							if typ == nil {
+								result = append(result, vv)
+								continue
 							}
 							info := types.NewTypePkgInfo(proj.ModPkg, "", imports)
-							findPackageV2(spec.Type, info)
+							findPackageV2(typ, info)
+							// Package 为声明变量的包, 类型所在的包记录在 TypePkgPath 中(与常量一致)
 							if info.Valid {
 								vv.Type = info.Name
 								vv.TypeName = info.FullName
-								if info.PkgType == constants.PackageOtherPackage {
+								vv.TypePkgPath = info.PkgPath
+								switch info.PkgType {
+								case constants.PackageOtherPackage:
 									vv.Struct = findType(info.PkgPath, info.Name, proj.BaseDir, proj.ModPkg, proj)
-									if vv.Struct != nil {
-										vv.Package = vv.Struct.Package.Clone()
-									}
-									vv.Package.Type = info.PkgType
-								} else {
-									vv.Package.Type = info.PkgType
-									vv.Package.Path = info.PkgPath
-									vv.Package.Name = info.PkgName
+								case constants.PackageSamePackage:
+									// 同包的类型, 其结构在 parseDir 中处理
+									vv.TypePkgPath = p.Path
 								}
 							}
 							result = append(result, vv)
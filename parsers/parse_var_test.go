@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/types"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func Test_parseVar(t *testing.T) {
+	node, _ := parser.ParseFile(token.NewFileSet(), "./tests/for_vars.txt", nil, parser.ParseComments)
+	proj := &types.Project{
+		BaseDir: "./tests",
+		ModPkg:  "tests",
+	}
+	vars := make(map[string]*types.Variable)
+	for _, v := range parseVar(node, &types.Package{Name: "tests", Path: "tests"}, proj, parseImport(node)) {
+		vars[v.Name] = v
+	}
+
+	def := vars["DefaultConfig"]
+	if def.Type != "Config" || def.TypePkgPath != "tests" || def.Package.Path != "tests" {
+		t.Fatalf("type: %s, type package: %s", def.Type, def.TypePkgPath)
+	}
+	if def.Init.Kind != constants.ExprComposite || def.Init.Type != "Config" || def.Init.Field("Port").Value != 8080 {
+		t.Fatal(def.Init.Text)
+	}
+	if tags := def.Init.Field("Tags"); tags.Kind != constants.ExprComposite || len(tags.Elts) != 2 || tags.Elts[1].Value != "b" {
+		t.Fatal(tags.Text)
+	}
+	if ref := vars["DefaultRef"]; ref.TypeName != "*Config" || ref.Init.Kind != constants.ExprUnary || ref.Init.Op != "&" {
+		t.Fatal(ref.TypeName)
+	}
+
+	err := vars["ErrNotFound"]
+	if err.Type != "error" || err.Init.Kind != constants.ExprCall || err.Init.Name != "New" || err.Init.Package.Path != "errors" {
+		t.Fatal(err.Type)
+	}
+	if err.Init.Args[0].Value != "not found" {
+		t.Fatal(err.Init.Args[0].Text)
+	}
+	// 无法推断类型的变量同样记录声明所在的包
+	if remote := vars["Remote"]; remote.Type != "" || remote.Package.Path != "tests" || remote.Init.Package.Path != "tests/config" || remote.Init.Package.Name != "cfg" {
+		t.Fatal(remote.Type)
+	}
+
+	if c := vars["Count"]; c.Type != "int" || c.TypePkgPath != "" || c.Package.Path != "tests" || c.Init != nil {
+		t.Fatal(c.Type)
+	}
+	if n := vars["Name"]; n.Type != "string" || n.Value != `"astp"` || n.Init.Value != "astp" {
+		t.Fatal(n.Value)
+	}
+	if a := vars["Alias"]; a.Init.Kind != constants.ExprIdent || a.Init.Name != "DefaultConfig" {
+		t.Fatal(a.Init.Kind)
+	}
+}
@@ -0,0 +1,18 @@
+package alias
+
+// Page generic page
+type Page[T any] struct {
+	Items []T
+	Total int64
+}
+
+// User user
+type User struct {
+	Name string
+}
+
+// UserPage alias of an instantiated generic
+type UserPage = Page[*User]
+
+// Set parameterised alias
+type Set[T comparable] = map[T]struct{}
@@ -0,0 +1,21 @@
+package tests
+
+// GetUser 详情
+// @Summary 第一行
+//
+//	第二行
+//	第三行
+//
+// @Description 很长的 \
+// 继续
+// @Example json
+// ```json
+//
+//	{
+//	  "id": 1,
+//	  "name": "a"
+//	}
+//
+// ```
+// @GET /users
+func GetUser() {}
@@ -0,0 +1,35 @@
+package generic_enum
+
+type Status int
+
+const (
+	Active Status = iota
+	Closed
+)
+
+type Resp[T any] struct {
+	Code int
+	Data T
+}
+
+type Base[E any] struct{}
+
+type Other[V any] struct{}
+
+// Get 详情
+// @GET /get
+func (b *Base[E]) Get(id E) {}
+
+type Holder struct {
+	S Status
+	R Resp[Status]
+}
+
+// Ctl 控制器
+// @Controller /ctl
+type Ctl struct {
+	Base[Status]
+	Other[string]
+}
+
+func Find(r Resp[Status]) {}
@@ -0,0 +1,46 @@
+package lint
+
+// UserCtl 用户
+// @Controler /user
+// @GET /users
+type UserCtl struct {
+	// @Ignore
+	Name string
+	// @Body extra
+	Age int
+}
+
+// List 用户列表
+// @GET /users
+// @POST /users
+func (u *UserCtl) List() {}
+
+// Detail 详情
+// @Route
+// @Deprecated use Get instead
+func (u *UserCtl) Detail() {}
+
+// Req 请求参数
+// @Body
+// @Body
+type Req struct {
+	ID int
+}
+
+// Get 详情
+// @Param(nope) path
+// @Param(id) get
+func Get(id int64) {}
+
+// Find 查找
+// @GET /users/{id}
+// @Param id path int true "user id"
+func (u *UserCtl) Find(id int64) {}
+
+// Put 更新
+// @Param(req) get
+func Put(
+	id int64,
+	req string,
+) {
+}
@@ -0,0 +1,24 @@
+package recursive
+
+// Base base
+type Base struct {
+	ID int64
+}
+
+// Node self-referential struct with an embedded parent
+type Node struct {
+	Base
+	Name     string
+	Children []*Node
+}
+
+// A references B
+type A struct {
+	B *B
+}
+
+// B references A
+type B struct {
+	A    *A
+	Leaf *Base
+}
@@ -0,0 +1,217 @@
+package types
+
+import (
+	"fmt"
+	"github.com/linxlib/astp/constants"
+	"strings"
+)
+
+var _ IElem[*AnnotationDef] = (*AnnotationDef)(nil)
+var _ IElem[*AnnotationArg] = (*AnnotationArg)(nil)
+
+// AnnotationArg 注解参数的定义
+type AnnotationArg struct {
+	Name     string   `json:"name"`               // 参数名称, key=value 参数为其 key eg. path / ttl
+	Type     string   `json:"type,omitempty"`     // 值的类型 string/int/bool/duration, 为空时不限
+	Required bool     `json:"required,omitempty"` // 是否必须
+	Keyword  bool     `json:"keyword,omitempty"`  // 以 key=value 形式给出
+	Variadic bool     `json:"variadic,omitempty"` // 最后一个位置参数, 可以有多个
+	Enum     []string `json:"enum,omitempty"`     // 可选的值
+}
+
+func (a *AnnotationArg) String() string {
+	return a.Name
+}
+
+func (a *AnnotationArg) Clone() *AnnotationArg {
+	if a == nil {
+		return nil
+	}
+	return &AnnotationArg{
+		Name:     a.Name,
+		Type:     a.Type,
+		Required: a.Required,
+		Keyword:  a.Keyword,
+		Variadic: a.Variadic,
+		Enum:     append([]string(nil), a.Enum...),
+	}
+}
+
+// AnnotationDef 注解的定义 eg. @Controller / @Ctl
+type AnnotationDef struct {
+	Name       string                 `json:"name"`                 // 名称(不含@) eg. Controller
+	AttrType   constants.AttrType     `json:"attr_type"`            // 内置注解的类型, 自定义注解为 AT_CUSTOM
+	Aliases    []string               `json:"aliases,omitempty"`    // 别名 eg. Ctl
+	Targets    []constants.AttrTarget `json:"targets,omitempty"`    // 可以标注的元素, 为空时不限
+	Args       []*AnnotationArg       `json:"args,omitempty"`       // 参数定义, 为nil时不检查参数, 为空时不允许有参数; 声明了 key=value 参数时不允许出现其他的 key
+	Repeatable bool                   `json:"repeatable,omitempty"` // 同一元素上是否可以出现多次
+	Group      string                 `json:"group,omitempty"`      // 同一元素上同组的注解只能出现一个 eg. http-method
+	Inherit    constants.AttrInherit  `json:"inherit,omitempty"`    // 嵌入结构时的继承规则, 为空时为 override
+	Doc        string                 `json:"doc,omitempty"`
+}
+
+func (d *AnnotationDef) String() string {
+	return "@" + d.Name
+}
+
+func (d *AnnotationDef) Clone() *AnnotationDef {
+	if d == nil {
+		return nil
+	}
+	return &AnnotationDef{
+		Name:       d.Name,
+		AttrType:   d.AttrType,
+		Aliases:    append([]string(nil), d.Aliases...),
+		Targets:    append([]constants.AttrTarget(nil), d.Targets...),
+		Args:       CopySlice(d.Args),
+		Repeatable: d.Repeatable,
+		Group:      d.Group,
+		Inherit:    d.Inherit,
+		Doc:        d.Doc,
+	}
+}
+
+// InheritMode 注解的继承规则, 未设置时为 override
+func (d *AnnotationDef) InheritMode() constants.AttrInherit {
+	if d == nil || d.Inherit == "" {
+		return constants.InheritOverride
+	}
+	return d.Inherit
+}
+
+// CanTarget 注解能否标注在某种元素上
+func (d *AnnotationDef) CanTarget(target constants.AttrTarget) bool {
+	if len(d.Targets) == 0 {
+		return true
+	}
+	for _, t := range d.Targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnotationRegistry 注解的注册表, 名称和别名不区分大小写
+type AnnotationRegistry struct {
+	defs  []*AnnotationDef
+	names map[string]*AnnotationDef
+}
+
+// NewAnnotationRegistry 包含内置注解的注册表
+func NewAnnotationRegistry() *AnnotationRegistry {
+	r := &AnnotationRegistry{names: make(map[string]*AnnotationDef)}
+	for _, def := range builtinAnnotations() {
+		// 内置注解之间不会冲突, 由测试保证
+		_ = r.Register(def)
+	}
+	return r
+}
+
+// Register 注册注解的拷贝(之后修改 def 不影响注册表), 名称或别名已被注册时返回错误
+func (r *AnnotationRegistry) Register(def *AnnotationDef) error {
+	if def == nil || def.Name == "" {
+		return fmt.Errorf("annotation name is empty")
+	}
+	def = def.Clone()
+	names := append([]string{def.Name}, def.Aliases...)
+	for _, name := range names {
+		if exists, ok := r.names[strings.ToUpper(name)]; ok {
+			return fmt.Errorf("annotation @%s already registered by %s", name, exists)
+		}
+	}
+	if def.AttrType == constants.AT_NONE {
+		def.AttrType = constants.AT_CUSTOM
+	}
+	r.defs = append(r.defs, def)
+	for _, name := range names {
+		r.names[strings.ToUpper(name)] = def
+	}
+	return nil
+}
+
+// Lookup 按名称或别名(不含@)查找注解, 未注册时返回nil
+func (r *AnnotationRegistry) Lookup(name string) *AnnotationDef {
+	return r.names[strings.ToUpper(strings.TrimPrefix(name, "@"))]
+}
+
+// Defs 所有已注册的注解(按注册顺序)
+func (r *AnnotationRegistry) Defs() []*AnnotationDef {
+	return CopySlice(r.defs)
+}
+
+// Annotations 默认的注解注册表, OfComment 使用它识别注解
+var Annotations = NewAnnotationRegistry()
+
+// RegisterAnnotation 向默认的注册表注册注解, 中间件或插件可在 init 中调用
+// eg. RegisterAnnotation(&AnnotationDef{Name: "RateLimit", Targets: []constants.AttrTarget{constants.TargetMethod}})
+func RegisterAnnotation(def *AnnotationDef) error {
+	return Annotations.Register(def)
+}
+
+// builtinAnnotations 内置的注解, 与 constants.AttrTypes 对应
+func builtinAnnotations() []*AnnotationDef {
+	noArgs := make([]*AnnotationArg, 0)
+	path := []*AnnotationArg{{Name: "path", Type: "string"}}
+	requiredPath := []*AnnotationArg{{Name: "path", Type: "string", Required: true}}
+	result := make([]*AnnotationDef, 0, len(constants.AttrTypes))
+	// 参数绑定, 标注在请求参数的结构上
+	bindings := []struct {
+		name     string
+		attrType constants.AttrType
+	}{
+		{"Body", constants.AT_BODY}, {"Query", constants.AT_QUERY}, {"Header", constants.AT_HEADER},
+		{"Path", constants.AT_PATH}, {"Plain", constants.AT_PLAIN}, {"Xml", constants.AT_XML},
+		{"Yaml", constants.AT_YAML}, {"Json", constants.AT_JSON}, {"Form", constants.AT_FORM},
+		{"Cookie", constants.AT_COOKIE}, {"Multipart", constants.AT_MULTIPART},
+	}
+	for _, b := range bindings {
+		result = append(result, &AnnotationDef{
+			Name:     b.name,
+			AttrType: b.attrType,
+			Targets:  []constants.AttrTarget{constants.TargetStruct, constants.TargetParam},
+			Args:     noArgs,
+		})
+	}
+	// http 方法, 标注在控制器的方法上
+	methods := []struct {
+		name     string
+		attrType constants.AttrType
+	}{
+		{"POST", constants.AT_POST}, {"GET", constants.AT_GET}, {"PUT", constants.AT_PUT},
+		{"DELETE", constants.AT_DELETE}, {"PATCH", constants.AT_PATCH}, {"OPTIONS", constants.AT_OPTIONS},
+		{"TRACE", constants.AT_TRACE}, {"ANY", constants.AT_ANY}, {"HEAD", constants.AT_HEAD},
+	}
+	for _, m := range methods {
+		result = append(result, &AnnotationDef{
+			Name:     m.name,
+			AttrType: m.attrType,
+			Targets:  []constants.AttrTarget{constants.TargetMethod},
+			Args:     path,
+			Group:    "http-method",
+		})
+	}
+	return append(result,
+		&AnnotationDef{Name: "Ignore", AttrType: constants.AT_IGNORE, Targets: []constants.AttrTarget{
+			constants.TargetStruct, constants.TargetMethod, constants.TargetFunction, constants.TargetField,
+		}, Args: noArgs, Inherit: constants.InheritNever},
+		&AnnotationDef{Name: "Route", AttrType: constants.AT_ROUTE, Targets: []constants.AttrTarget{
+			constants.TargetStruct, constants.TargetMethod,
+		}, Args: requiredPath},
+		&AnnotationDef{Name: "Controller", AttrType: constants.AT_CONTROLLER, Aliases: []string{"Ctl"},
+			Targets: []constants.AttrTarget{constants.TargetStruct}, Args: path},
+		&AnnotationDef{Name: "Base", AttrType: constants.AT_BASE,
+			Targets: []constants.AttrTarget{constants.TargetStruct}, Args: requiredPath},
+		&AnnotationDef{Name: "Service", AttrType: constants.AT_SERVICE, Targets: []constants.AttrTarget{constants.TargetStruct},
+			Args: noArgs, Inherit: constants.InheritNever},
+		&AnnotationDef{Name: "Entity", AttrType: constants.AT_ENTITY, Targets: []constants.AttrTarget{constants.TargetStruct},
+			Args: noArgs, Inherit: constants.InheritNever},
+		&AnnotationDef{Name: "Table", AttrType: constants.AT_TABLE, Targets: []constants.AttrTarget{constants.TargetStruct},
+			Args: []*AnnotationArg{{Name: "name", Type: "string", Required: true}}, Inherit: constants.InheritNever},
+		&AnnotationDef{Name: "Param", AttrType: constants.AT_PARAM, Targets: []constants.AttrTarget{constants.TargetMethod, constants.TargetFunction},
+			Args:       []*AnnotationArg{{Name: "attr", Type: "string", Required: true}, {Name: "args", Variadic: true}},
+			Repeatable: true, Inherit: constants.InheritNever, Doc: "为指定的参数添加注解 eg. @Param(id) path"},
+		&AnnotationDef{Name: "Deprecated", AttrType: constants.AT_DEPRECATED,
+			Args: []*AnnotationArg{{Name: "reason", Type: "string", Variadic: true}}, Inherit: constants.InheritNever},
+	)
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"testing"
+)
+
+func Test_AnnotationRegistry(t *testing.T) {
+	if c := OfComment(0, "@Ctl /api", "UserCtl"); c.AttrType != constants.AT_CONTROLLER || c.Annotation != "Controller" || c.CustomAttr != "" {
+		t.Fatal(c.AttrType, c.Annotation)
+	}
+	if c := OfComment(0, "@Controler", "UserCtl"); c.AttrType != constants.AT_CUSTOM || c.Annotation != "" || c.CustomAttr != "CONTROLER" {
+		t.Fatal(c.AttrType, c.Annotation)
+	}
+
+	r := NewAnnotationRegistry()
+	if err := r.Register(&AnnotationDef{Name: "ctl"}); err == nil {
+		t.Fatal("alias conflict")
+	}
+	def := &AnnotationDef{Name: "RateLimit", Aliases: []string{"RL"}, Targets: []constants.AttrTarget{constants.TargetMethod}}
+	if err := r.Register(def); err != nil {
+		t.Fatal(err)
+	}
+	registered := r.Lookup("@rl")
+	if registered == nil || registered == def || registered.AttrType != constants.AT_CUSTOM || !registered.CanTarget(constants.TargetMethod) || registered.CanTarget(constants.TargetStruct) {
+		t.Fatal(registered)
+	}
+	// 注册的是拷贝, 之后修改 def 不影响注册表
+	def.Name = "Throttle"
+	def.Aliases[0] = "TH"
+	if def.AttrType != constants.AT_NONE || r.Lookup("RL").Name != "RateLimit" || r.Lookup("TH") != nil {
+		t.Fatal(r.Lookup("RL"))
+	}
+	if Annotations.Lookup("RateLimit") != nil {
+		t.Fatal("registered to the default registry")
+	}
+}
+
+func Test_builtinAnnotations(t *testing.T) {
+	// 内置注解的名称和别名不能冲突
+	r := &AnnotationRegistry{names: make(map[string]*AnnotationDef)}
+	for _, def := range builtinAnnotations() {
+		if err := r.Register(def); err != nil {
+			t.Fatalf("builtin %s: %v", def, err)
+		}
+	}
+}
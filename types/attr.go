@@ -0,0 +1,138 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"strings"
+)
+
+// 字段, 参数和枚举项的注解, 与 Struct/Function 的 HasAttr/GetAttrs/GetAttrValue 一致
+// 字段和枚举项的注解可以写在上方(Doc)或行尾(Comment), 参数的注解来自方法注释中的 @Param(name)
+
+func hasAttr(attr constants.AttrType, groups ...[]*Comment) bool {
+	for _, comments := range groups {
+		for _, comment := range comments {
+			if comment.Op && comment.AttrType == attr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasCustomAttr(attr string, groups ...[]*Comment) bool {
+	upper := strings.ToUpper(attr)
+	if v, ok := constants.AttrTypes[upper]; ok {
+		return hasAttr(v, groups...)
+	}
+	for _, comments := range groups {
+		for _, comment := range comments {
+			if comment.Op && comment.CustomAttr == upper {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getAttrs(groups ...[]*Comment) []*Comment {
+	result := make([]*Comment, 0)
+	for _, comments := range groups {
+		result = append(result, CopySliceWithFilter(comments, func(comment *Comment) bool {
+			return comment.Op
+		})...)
+	}
+	return result
+}
+
+func getAttrValue(attr constants.AttrType, groups ...[]*Comment) string {
+	for _, comments := range groups {
+		for _, comment := range comments {
+			if comment.Op && comment.AttrType == attr {
+				return comment.AttrValue
+			}
+		}
+	}
+	return ""
+}
+
+func getCustomAttrs(groups ...[]*Comment) []*Comment {
+	result := make([]*Comment, 0)
+	for _, comment := range getAttrs(groups...) {
+		if comment.AttrType == constants.AT_CUSTOM {
+			result = append(result, comment)
+		}
+	}
+	return result
+}
+
+func (f *Field) HasAttr(attr constants.AttrType) bool {
+	return hasAttr(attr, f.Doc, f.Comment)
+}
+
+func (f *Field) HasCustomAttr(attr string) bool {
+	return hasCustomAttr(attr, f.Doc, f.Comment)
+}
+
+func (f *Field) GetAttrs() []*Comment {
+	return getAttrs(f.Doc, f.Comment)
+}
+
+func (f *Field) GetAttrValue(attr constants.AttrType) string {
+	return getAttrValue(attr, f.Doc, f.Comment)
+}
+
+func (f *Field) GetCustomAttrs() []*Comment {
+	return getCustomAttrs(f.Doc, f.Comment)
+}
+
+func (p *Param) HasAttr(attr constants.AttrType) bool {
+	return hasAttr(attr, p.Doc)
+}
+
+func (p *Param) HasCustomAttr(attr string) bool {
+	return hasCustomAttr(attr, p.Doc)
+}
+
+func (p *Param) GetAttrs() []*Comment {
+	return getAttrs(p.Doc)
+}
+
+func (p *Param) GetAttrValue(attr constants.AttrType) string {
+	return getAttrValue(attr, p.Doc)
+}
+
+func (p *Param) GetCustomAttrs() []*Comment {
+	return getCustomAttrs(p.Doc)
+}
+
+func (e *EnumItem) HasAttr(attr constants.AttrType) bool {
+	return hasAttr(attr, e.Doc, e.Comment)
+}
+
+func (e *EnumItem) HasCustomAttr(attr string) bool {
+	return hasCustomAttr(attr, e.Doc, e.Comment)
+}
+
+func (e *EnumItem) GetAttrs() []*Comment {
+	return getAttrs(e.Doc, e.Comment)
+}
+
+func (e *EnumItem) GetAttrValue(attr constants.AttrType) string {
+	return getAttrValue(attr, e.Doc, e.Comment)
+}
+
+func (e *EnumItem) GetCustomAttrs() []*Comment {
+	return getCustomAttrs(e.Doc, e.Comment)
+}
+
+// ParamAttrs 返回方法注释中 @Param(name) 指定给某个参数的注解(不含 @Param 本身), 没有值的 @Param(name) 会被忽略
+// eg. @Param(id) path -> @path
+func ParamAttrs(doc []*Comment, name string) []*Comment {
+	result := make([]*Comment, 0)
+	for _, comment := range doc {
+		if comment.Op && comment.Target == name && comment.AttrType == constants.AT_PARAM && comment.AttrValue != "" {
+			result = append(result, OfComment(comment.Index, "@"+comment.AttrValue, name))
+		}
+	}
+	return result
+}
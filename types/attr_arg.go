@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var _ IElem[*AttrArg] = (*AttrArg)(nil)
+
+// AttrArg 注解的一个参数
+// eg. @GET /users/{id} auth=admin cache=60s 中的 /users/{id}, auth=admin, cache=60s
+// eg. @Param id path int true "user id" 中的 "user id" (Value 为去掉引号的 user id)
+type AttrArg struct {
+	Index  int    `json:"index"`            // 在注解中的位置(所有参数一起计数)
+	Key    string `json:"key,omitempty"`    // key=value 形式的 key, 位置参数为空
+	Value  string `json:"value"`            // 值, 带引号的会去掉引号并处理转义
+	Quoted bool   `json:"quoted,omitempty"` // 值是否带引号
+	Text   string `json:"text"`             // 原始文本 eg. title="a b"
+}
+
+func (a *AttrArg) String() string {
+	return a.Text
+}
+
+func (a *AttrArg) Clone() *AttrArg {
+	if a == nil {
+		return nil
+	}
+	return &AttrArg{
+		Index:  a.Index,
+		Key:    a.Key,
+		Value:  a.Value,
+		Quoted: a.Quoted,
+		Text:   a.Text,
+	}
+}
+
+// ParseAttrArgs 将注解名称之后的内容拆分为参数
+// 以空白分隔; 支持 "..." (可转义) 和 `...` 的字符串; key=value 中的 key 需为标识符(可包含 . 和 -)
+// 引号未闭合时, 剩余部分整体作为一个值, 同时返回错误
+func ParseAttrArgs(s string) ([]*AttrArg, error) {
+	args := make([]*AttrArg, 0)
+	var err error
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimLeftFunc(s, unicode.IsSpace) {
+		arg := &AttrArg{Index: len(args)}
+		if i := attrKeyEnd(s); i > 0 {
+			arg.Key = s[:i]
+		}
+		rest := s[len(arg.Key):]
+		if arg.Key != "" {
+			// 跳过 =
+			rest = rest[1:]
+		}
+		var n int
+		if rest != "" && (rest[0] == '"' || rest[0] == '`') {
+			arg.Quoted = true
+			var e error
+			n, arg.Value, e = attrQuoted(rest)
+			if err == nil {
+				err = e
+			}
+		} else {
+			n = strings.IndexFunc(rest, unicode.IsSpace)
+			if n < 0 {
+				n = len(rest)
+			}
+			arg.Value = rest[:n]
+		}
+		n += len(s) - len(rest)
+		arg.Text = s[:n]
+		s = s[n:]
+		args = append(args, arg)
+	}
+	return args, err
+}
+
+// attrKeyEnd key=value 中 = 的位置, 不是 key=value 形式时返回 -1
+func attrKeyEnd(s string) int {
+	for i, r := range s {
+		switch {
+		case r == '=':
+			if i == 0 {
+				return -1
+			}
+			return i
+		case r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)):
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// attrQuoted 读取以引号开头的值, 返回消耗的长度和去掉引号后的值
+func attrQuoted(s string) (int, string, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' && quote == '"' {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			value, e := strconv.Unquote(s[:i+1])
+			if e != nil {
+				return i + 1, s[1:i], fmt.Errorf("invalid quoted string %s", s[:i+1])
+			}
+			return i + 1, value, nil
+		}
+	}
+	return len(s), s[1:], fmt.Errorf("unterminated quoted string %s", s)
+}
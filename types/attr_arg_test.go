@@ -0,0 +1,35 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"testing"
+)
+
+func Test_OfCommentArgs(t *testing.T) {
+	c := OfComment(0, `@GET /users/{id} auth=admin cache=60s`, "List")
+	if c.AttrType != constants.AT_GET || c.AttrValue != "/users/{id} auth=admin cache=60s" || len(c.Args) != 3 {
+		t.Fatal(c.Args)
+	}
+	if c.Arg(0) != "/users/{id}" || c.Arg(1) != "" {
+		t.Fatal(c.Arg(0))
+	}
+	if v, ok := c.KwArg("cache"); !ok || v != "60s" || c.Args[1].Text != "auth=admin" {
+		t.Fatal(v)
+	}
+
+	c = OfComment(0, `@Param id path int true "user \"id\"" title=`+"`a b`", "Get")
+	if c.AttrType != constants.AT_PARAM || len(c.Args) != 6 || c.Arg(4) != `user "id"` || !c.Args[4].Quoted {
+		t.Fatal(c.Args)
+	}
+	if v, _ := c.KwArg("title"); v != "a b" || c.Args[5].Text != "title=`a b`" {
+		t.Fatal(v)
+	}
+
+	args, err := ParseAttrArgs(`a "b c`)
+	if err == nil || len(args) != 2 || args[1].Value != "b c" {
+		t.Fatal(args, err)
+	}
+	if c := OfComment(0, "List 用户列表", "List"); c.Args != nil {
+		t.Fatal(c.Args)
+	}
+}
@@ -17,6 +17,12 @@ type Comment struct {
 	AttrType   constants.AttrType `json:"attr_type"`
 	CustomAttr string             `json:"custom_attr,omitempty"`
 	AttrValue  string             `json:"attr_value,omitempty"`
+	Annotation string             `json:"annotation,omitempty"` // 已注册注解的名称(别名会转为名称) eg. @Ctl -> Controller, 未注册时为空
+	Args       []*AttrArg         `json:"args,omitempty"`       // 注解的参数(位置参数和key=value) eg. @GET /users/{id} auth=admin
+	Block      string             `json:"block,omitempty"`      // 注解之后 ``` 包围的代码块 eg. @Example 的 json
+	Lang       string             `json:"lang,omitempty"`       // 代码块的语言 eg. ```json 中的 json
+	Target     string             `json:"target,omitempty"`     // 注解指定的目标 eg. @Param(id) path 中的 id
+	Origin     string             `json:"origin,omitempty"`     // 从嵌入的上级结构继承的注解, 为声明该注解的结构 eg. base.BaseCtl
 }
 
 func (c *Comment) String() string {
@@ -35,6 +41,12 @@ func (c *Comment) Clone() *Comment {
 		AttrType:   c.AttrType,
 		CustomAttr: c.CustomAttr,
 		AttrValue:  c.AttrValue,
+		Annotation: c.Annotation,
+		Args:       CopySlice(c.Args),
+		Block:      c.Block,
+		Lang:       c.Lang,
+		Target:     c.Target,
+		Origin:     c.Origin,
 	}
 }
 
@@ -46,16 +58,33 @@ func OfComment(index int, content string, selfName string) *Comment {
 	var attrType = constants.AT_NONE
 	attrCustom := ""
 	attrValue := ""
+	annotation := ""
+	target := ""
+	var args []*AttrArg
 	var op = false
 	isSelf := false
+	var matches [][]string
 	if strings.HasPrefix(content, "@") {
 		re := regexp.MustCompile(`@(\S+)`)
-		matches := re.FindAllStringSubmatch(content, -1)
+		matches = re.FindAllStringSubmatch(content, -1)
+	}
+	// 只有 @ 时不是注解 eg. // @
+	if len(matches) > 0 {
 		tmp0 := "@" + matches[0][1]
-		tmp := strings.ToUpper(matches[0][1])
+		name := matches[0][1]
+		// 带目标的注解 eg. @Param(id)
+		if i := strings.IndexByte(name, '('); i > 0 && strings.HasSuffix(name, ")") {
+			target = name[i+1 : len(name)-1]
+			name = name[:i]
+		}
+		tmp := strings.ToUpper(name)
 		op = true
-		if v, ok := constants.AttrTypes[tmp]; ok {
-			attrType = v
+		if def := Annotations.Lookup(name); def != nil {
+			annotation = def.Name
+			attrType = def.AttrType
+			if attrType == constants.AT_CUSTOM {
+				attrCustom = strings.ToUpper(def.Name)
+			}
 		} else {
 			attrType = constants.AT_CUSTOM
 			attrCustom = tmp
@@ -64,6 +93,8 @@ func OfComment(index int, content string, selfName string) *Comment {
 		a = strings.TrimPrefix(a, tmp0)
 		a = strings.TrimSpace(a)
 		attrValue = a
+		// 参数语法错误时保留已解析的部分
+		args, _ = ParseAttrArgs(a)
 	} else {
 		if strings.HasPrefix(strings.TrimSpace(content), selfName) {
 			isSelf = true
@@ -82,8 +113,162 @@ func OfComment(index int, content string, selfName string) *Comment {
 		AttrType:   attrType,
 		CustomAttr: attrCustom,
 		AttrValue:  attrValue,
+		Annotation: annotation,
+		Args:       args,
+		Target:     target,
+	}
+}
+
+// OfComments 解析一组注释行(只去掉了 //, 保留缩进), 注解的值可以跨多行, 合并为一个 Comment
+// 1. 以 \ 结尾的行与下一行以空格拼接 eg. @Description 很长的 \
+// 2. 注解之后缩进的行以换行拼接; gofmt 会将其格式化为空行加上以 tab 缩进的代码块, 两种形式都支持
+// 3. 注解之后紧跟的 ``` 代码块附加到该注解的 Block 中, 同时拼接到 AttrValue
+func OfComments(lines []string, selfName string) []*Comment {
+	result := make([]*Comment, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		content := strings.TrimLeft(lines[i], " ")
+		c := OfComment(i, content, selfName)
+		result = append(result, c)
+		if !c.Op {
+			continue
+		}
+		raw := []string{content}
+		value := c.AttrValue
+		gofmtBlock := false
+	continuation:
+		for i+1 < len(lines) {
+			next := strings.TrimSpace(lines[i+1])
+			switch {
+			case strings.HasSuffix(value, "\\"):
+				value = strings.TrimSpace(strings.TrimSuffix(value, "\\")) + " " + next
+			case next != "" && isIndentedComment(lines[i+1]):
+				value = strings.TrimSpace(value + "\n" + next)
+			case next == "" && !gofmtBlock && isGofmtBlock(lines, i+2):
+				// gofmt 在缩进的行之前插入的空行
+				gofmtBlock = true
+			default:
+				break continuation
+			}
+			raw = append(raw, strings.TrimLeft(lines[i+1], " "))
+			i++
+		}
+		if gofmtBlock && i+1 < len(lines) && strings.TrimSpace(lines[i+1]) == "" {
+			// gofmt 在缩进的行之后插入的空行
+			raw = append(raw, "")
+			i++
+		}
+		if i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "```") {
+			fence := strings.TrimSpace(lines[i+1])
+			raw = append(raw, fence)
+			i++
+			block := make([]string, 0)
+			for i+1 < len(lines) {
+				i++
+				raw = append(raw, strings.TrimLeft(lines[i], " "))
+				if strings.TrimSpace(lines[i]) == "```" {
+					break
+				}
+				block = append(block, lines[i])
+			}
+			c.Lang = strings.TrimSpace(strings.TrimLeft(fence, "`"))
+			c.Block = strings.Join(trimBlockIndent(block), "\n")
+		}
+		if len(raw) == 1 {
+			continue
+		}
+		c.Content = strings.Join(raw, "\n")
+		c.Args, _ = ParseAttrArgs(value)
+		c.AttrValue = strings.TrimSpace(value + "\n" + c.Block)
+	}
+	return result
+}
+
+// isGofmtBlock 第i行开始是否为 gofmt 格式化后的代码块(以 tab 缩进)
+func isGofmtBlock(lines []string, i int) bool {
+	return i < len(lines) && strings.HasPrefix(lines[i], "\t")
+}
+
+// trimBlockIndent 去掉代码块每行 // 之后的一个空格, 保留代码块中的缩进
+// gofmt 格式化后的代码块(首尾为空行, 其余各行以 tab 缩进)去掉首尾的空行和每行的 tab
+func trimBlockIndent(block []string) []string {
+	start, end := 0, len(block)
+	for start < end && strings.TrimSpace(block[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(block[end-1]) == "" {
+		end--
+	}
+	gofmt := start < end
+	for _, line := range block[start:end] {
+		if line != "" && !strings.HasPrefix(line, "\t") {
+			gofmt = false
+			break
+		}
+	}
+	result := make([]string, 0, len(block))
+	if gofmt {
+		for _, line := range block[start:end] {
+			result = append(result, strings.TrimPrefix(line, "\t"))
+		}
+		return result
 	}
+	for _, line := range block {
+		result = append(result, strings.TrimPrefix(line, " "))
+	}
+	return result
+}
+
+// isIndentedComment 去掉 // 之后的一个空格, 仍以空白开头的行
+func isIndentedComment(line string) bool {
+	return strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, " \t")
+}
+
+// InlineValue 注解在代码块之前的值 eg. @Example json 中的 json
+func (c *Comment) InlineValue() string {
+	if c.Block == "" {
+		return c.AttrValue
+	}
+	return strings.TrimSpace(strings.TrimSuffix(c.AttrValue, c.Block))
+}
+
+// AttrName 注解的名称(不含@和目标), 不是注解时返回空字符串 eg. @Ctl /api -> Ctl / @Param(id) path -> Param
+func (c *Comment) AttrName() string {
+	if !c.Op {
+		return ""
+	}
+	fields := strings.Fields(c.Content)
+	if len(fields) == 0 {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(fields[0], "@"), "(")
+	return name
 }
+
+// Arg 返回第i个位置参数(不含key=value参数)的值, 没有时返回空字符串
+// eg. @Param id path int true "user id" 中 Arg(1) 为 path
+func (c *Comment) Arg(i int) string {
+	for _, arg := range c.Args {
+		if arg.Key != "" {
+			continue
+		}
+		if i == 0 {
+			return arg.Value
+		}
+		i--
+	}
+	return ""
+}
+
+// KwArg 返回key=value参数的值 eg. @GET /users auth=admin 中 KwArg("auth") 为 admin
+func (c *Comment) KwArg(key string) (string, bool) {
+	for _, arg := range c.Args {
+		if arg.Key == key {
+			return arg.Value, true
+		}
+	}
+	return "", false
+}
+
 func (c *Comment) IsHttpMethod() bool {
 
 	return c.Op && (c.AttrType == constants.AT_ANY ||
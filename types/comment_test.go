@@ -0,0 +1,43 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"testing"
+)
+
+func Test_OfComments(t *testing.T) {
+	lines := []string{
+		" List 用户列表",
+		" @Description 返回所有用户, \\",
+		" 按创建时间倒序",
+		" @Summary 第一行",
+		"   第二行",
+		" @Example json",
+		" ```json",
+		" {",
+		"   \"id\": 1",
+		" }",
+		" ```",
+		" @GET /users",
+	}
+	list := OfComments(lines, "List")
+	if len(list) != 5 || !list[0].IsSelf {
+		t.Fatal(list)
+	}
+	if d := list[1]; d.CustomAttr != "DESCRIPTION" || d.AttrValue != "返回所有用户, 按创建时间倒序" || d.Content != "@Description 返回所有用户, \\\n按创建时间倒序" {
+		t.Fatal(d.AttrValue)
+	}
+	if s := list[2]; s.AttrValue != "第一行\n第二行" || s.Index != 3 || len(s.Args) != 2 {
+		t.Fatal(s.AttrValue)
+	}
+	e := list[3]
+	if e.Lang != "json" || e.Block != "{\n  \"id\": 1\n}" || e.InlineValue() != "json" || e.Arg(0) != "json" {
+		t.Fatal(e.Block)
+	}
+	if e.AttrValue != "json\n"+e.Block {
+		t.Fatal(e.AttrValue)
+	}
+	if g := list[4]; g.AttrType != constants.AT_GET || g.AttrValue != "/users" || g.Index != 11 {
+		t.Fatal(g.AttrValue)
+	}
+}
@@ -0,0 +1,98 @@
+package types
+
+import "github.com/linxlib/astp/constants"
+
+var _ IElem[*Constraint] = (*Constraint)(nil)
+var _ IElem[*ConstraintTerm] = (*ConstraintTerm)(nil)
+
+// Constraint 泛型类型参数的约束
+// eg. any / comparable / ~int | ~string / Model / interface{ ~int; String() string }
+type Constraint struct {
+	Expr       string            `json:"expr"` // 约束的原始写法
+	Any        bool              `json:"any,omitempty"`
+	Comparable bool              `json:"comparable,omitempty"`
+	Terms      []*ConstraintTerm `json:"terms,omitempty"`  // 类型集合 ~int | ~string
+	Ref        []*ConstraintTerm `json:"ref,omitempty"`    // 引用的约束接口 eg. Model / fmt.Stringer / constraints.Ordered
+	Method     []*Interface      `json:"method,omitempty"` // 内联接口中声明的方法
+}
+
+func (c *Constraint) String() string {
+	return c.Expr
+}
+
+func (c *Constraint) Clone() *Constraint {
+	if c == nil {
+		return nil
+	}
+	return &Constraint{
+		Expr:       c.Expr,
+		Any:        c.Any,
+		Comparable: c.Comparable,
+		Terms:      CopySlice(c.Terms),
+		Ref:        CopySlice(c.Ref),
+		Method:     CopySlice(c.Method),
+	}
+}
+
+// IsUnion 约束是否为类型集合 eg. ~int | ~string
+func (c *Constraint) IsUnion() bool {
+	return c != nil && len(c.Terms) > 0
+}
+
+// TermTypes 返回类型集合中的各个类型名
+func (c *Constraint) TermTypes() []string {
+	if c == nil {
+		return nil
+	}
+	result := make([]string, 0, len(c.Terms))
+	for _, term := range c.Terms {
+		result = append(result, term.TypeName)
+	}
+	return result
+}
+
+// Merge 合并内联接口中嵌入的约束
+func (c *Constraint) Merge(other *Constraint) {
+	if other == nil {
+		return
+	}
+	c.Comparable = c.Comparable || other.Comparable
+	c.Terms = append(c.Terms, other.Terms...)
+	c.Ref = append(c.Ref, other.Ref...)
+	c.Method = append(c.Method, other.Method...)
+}
+
+// ConstraintTerm 约束中的一项
+// 在类型集合中 eg. ~int, 或是被引用的约束接口 eg. Model
+type ConstraintTerm struct {
+	Tilde    bool     `json:"tilde,omitempty"` // ~int
+	Type     string   `json:"type"`
+	TypeName string   `json:"type_name"`
+	Package  *Package `json:"package,omitempty"`
+	Struct   *Struct  `json:"struct,omitempty"`
+}
+
+func (t *ConstraintTerm) String() string {
+	if t.Tilde {
+		return "~" + t.TypeName
+	}
+	return t.TypeName
+}
+
+func (t *ConstraintTerm) Clone() *ConstraintTerm {
+	if t == nil {
+		return nil
+	}
+	return &ConstraintTerm{
+		Tilde:    t.Tilde,
+		Type:     t.Type,
+		TypeName: t.TypeName,
+		Package:  t.Package.Clone(),
+		Struct:   t.Struct.Clone(),
+	}
+}
+
+// IsBuiltin 是否为内置类型 eg. int / string
+func (t *ConstraintTerm) IsBuiltin() bool {
+	return t.Package != nil && t.Package.Type == constants.PackageBuiltin
+}
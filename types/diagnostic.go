@@ -0,0 +1,54 @@
+package types
+
+import (
+	"cmp"
+	"github.com/linxlib/astp/constants"
+	"strconv"
+	"strings"
+)
+
+var _ IElem[*Diagnostic] = (*Diagnostic)(nil)
+
+// Diagnostic 解析过程中发现的问题 eg. 错误的结构体标签
+type Diagnostic struct {
+	Level   constants.DiagLevel `json:"level"`
+	Code    string              `json:"code"`          // eg. invalid-tag
+	Pos     string              `json:"pos,omitempty"` // 相对于项目目录 eg. controllers/user.go:12
+	Message string              `json:"message"`
+}
+
+func (d *Diagnostic) String() string {
+	return d.Pos + ": " + d.Message
+}
+
+func (d *Diagnostic) Clone() *Diagnostic {
+	if d == nil {
+		return nil
+	}
+	return &Diagnostic{
+		Level:   d.Level,
+		Code:    d.Code,
+		Pos:     d.Pos,
+		Message: d.Message,
+	}
+}
+
+// splitPos 将位置拆为文件和行号 eg. controllers/user.go:12 -> controllers/user.go, 12
+func (d *Diagnostic) splitPos() (string, int) {
+	idx := strings.LastIndexByte(d.Pos, ':')
+	if idx < 0 {
+		return d.Pos, 0
+	}
+	line, _ := strconv.Atoi(d.Pos[idx+1:])
+	return d.Pos[:idx], line
+}
+
+// ComparePos 先按文件再按行号比较位置, 用于排序 eg. user.go:9 在 user.go:12 之前
+func (d *Diagnostic) ComparePos(other *Diagnostic) int {
+	file, line := d.splitPos()
+	otherFile, otherLine := other.splitPos()
+	if c := strings.Compare(file, otherFile); c != 0 {
+		return c
+	}
+	return cmp.Compare(line, otherLine)
+}
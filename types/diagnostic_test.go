@@ -0,0 +1,24 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_DiagnosticComparePos(t *testing.T) {
+	diags := []*Diagnostic{
+		{Pos: "models/user.go:12"},
+		{Pos: "controllers/user.go:30"},
+		{Pos: "models/user.go:9"},
+		{Pos: "controllers/user.go:4"},
+	}
+	slices.SortFunc(diags, func(a, b *Diagnostic) int {
+		return a.ComparePos(b)
+	})
+	want := []string{"controllers/user.go:4", "controllers/user.go:30", "models/user.go:9", "models/user.go:12"}
+	for i, d := range diags {
+		if d.Pos != want[i] {
+			t.Fatal(d.Pos)
+		}
+	}
+}
@@ -49,6 +49,9 @@ type TypePkgInfo struct {
 	Valid      bool
 	Pointer    bool
 	Slice      bool
+	Variadic   bool
+	Chan       bool
+	ChanDir    constants.ChanDir
 	PkgName    string
 	Name       string
 	PkgType    constants.PackageType
@@ -56,8 +59,35 @@ type TypePkgInfo struct {
 	Generic    bool
 	FullName   string
 	Children   []*TypePkgInfo
+	// 当前作用域中声明的泛型参数名(结构/接收器/函数的类型参数)
+	TypeParams []string
 }
 
 func NewTypePkgInfo(modPkg string, currentPkg string, imports []*Import) *TypePkgInfo {
 	return &TypePkgInfo{Imports: imports, ModPkg: modPkg, CurrentPkg: currentPkg}
 }
+
+// WithTypeParams 设置当前作用域中声明的泛型参数
+func (t *TypePkgInfo) WithTypeParams(tps []*TypeParam) *TypePkgInfo {
+	for _, tp := range tps {
+		t.TypeParams = append(t.TypeParams, tp.Type)
+	}
+	return t
+}
+
+// IsTypeParam name 是否为当前作用域中声明的泛型参数
+func (t *TypePkgInfo) IsTypeParam(name string) bool {
+	for _, tp := range t.TypeParams {
+		if tp == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewChild 创建一个继承当前作用域的子节点
+func (t *TypePkgInfo) NewChild() *TypePkgInfo {
+	child := NewTypePkgInfo(t.ModPkg, t.CurrentPkg, t.Imports)
+	child.TypeParams = t.TypeParams
+	return child
+}
@@ -0,0 +1,218 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"slices"
+	"strings"
+)
+
+// SelectorName 字段的选择器名称, 匿名字段为其类型名 eg. *base.Ctl -> Ctl
+func (f *Field) SelectorName() string {
+	if f.Parent {
+		return f.Type
+	}
+	return f.Name
+}
+
+// JSONName encoding/json 中使用的名称, 即json标签中的名称, 没有则为选择器名称
+func (f *Field) JSONName() string {
+	return f.WireName(constants.CodecJSON)
+}
+
+// WireName 字段在某种编码下的键, 即对应标签中的名称, 没有则为选择器名称 (yaml 为其小写)
+func (f *Field) WireName(codec constants.Codec) string {
+	if tag := f.TagOf(codec); tag != nil && tag.Name != "" {
+		return tag.Name
+	}
+	if codec == constants.CodecYAML {
+		return strings.ToLower(f.SelectorName())
+	}
+	return f.SelectorName()
+}
+
+// inlined 嵌入结构在某种编码下是否展开其字段
+// json/form 中带名称的嵌入结构视为普通字段, xml 总是展开, yaml 仅在有 inline 选项时展开
+func (f *Field) inlined(codec constants.Codec) bool {
+	tag := f.TagOf(codec)
+	switch codec {
+	case constants.CodecXML:
+		return true
+	case constants.CodecYAML:
+		return tag.HasOption("inline")
+	}
+	return tag == nil || tag.Name == ""
+}
+
+// embeddedFields 返回嵌入结构可被提升的字段(声明时的字段), 不可展开时返回 nil
+// 嵌入的接口只提升方法, 不提升字段
+func (f *Field) embeddedFields() []*Field {
+	if !f.Parent || f.Struct == nil || f.Struct.ElemType == constants.ElemInterface {
+		return nil
+	}
+	if f.Struct.Declared != nil {
+		return f.Struct.Declared
+	}
+	return f.Struct.Field
+}
+
+// fieldCandidate 字段选择时的候选字段
+type fieldCandidate struct {
+	field  *Field
+	name   string
+	depth  int
+	tagged bool
+	// 已展开的嵌入结构, 仅参与同名字段的遮蔽, 不出现在结果中
+	embed bool
+}
+
+// collectFields 按声明顺序(深度优先)收集所有层级的字段, depth 为嵌入的层数
+// codec 为空时按 Go 的选择器规则, 否则按对应编码的规则
+func collectFields(fields []*Field, depth int, path string, visiting map[string]bool, codec constants.Codec, out *[]*fieldCandidate) {
+	for _, f := range fields {
+		c := &fieldCandidate{name: f.SelectorName(), depth: depth}
+		embedded := f.embeddedFields()
+		if codec != "" {
+			tag := f.TagOf(codec)
+			if tag.Ignored() || (codec == constants.CodecXML && f.Name == "XMLName") {
+				// XMLName 只决定元素名称, 不是属性
+				continue
+			}
+			c.name = f.WireName(codec)
+			c.tagged = tag != nil && tag.Name != ""
+			if !f.inlined(codec) {
+				embedded = nil
+			}
+		}
+		if (depth > 0 || codec != "") && f.Private && embedded == nil {
+			continue
+		}
+		if embedded != nil && !visiting[f.Struct.TypeName] {
+			if codec == "" {
+				c.embed = true
+				c.field = f
+				*out = append(*out, c)
+			}
+			visiting[f.Struct.TypeName] = true
+			next := f.SelectorName()
+			if path != "" {
+				next = path + "." + next
+			}
+			collectFields(embedded, depth+1, next, visiting, codec, out)
+			delete(visiting, f.Struct.TypeName)
+			continue
+		}
+		c.field = f.Clone()
+		c.field.Depth = depth
+		c.field.Embed = path
+		*out = append(*out, c)
+	}
+}
+
+// selectFields 按名称挑选字段
+// 同名时层级最浅的字段胜出; 同一层级有多个时(编码规则下优先带标签名称的)视为冲突, 全部丢弃
+func selectFields(candidates []*fieldCandidate, codec constants.Codec) []*Field {
+	dominant := make(map[string]*fieldCandidate)
+	groups := make(map[string][]*fieldCandidate)
+	for _, c := range candidates {
+		groups[c.name] = append(groups[c.name], c)
+	}
+	for name, group := range groups {
+		depth := group[0].depth
+		shallowest := make([]*fieldCandidate, 0, len(group))
+		for _, c := range group {
+			if c.depth < depth {
+				depth = c.depth
+				shallowest = shallowest[:0]
+			}
+			if c.depth == depth {
+				shallowest = append(shallowest, c)
+			}
+		}
+		if len(shallowest) > 1 && codec != "" {
+			tagged := make([]*fieldCandidate, 0, len(shallowest))
+			for _, c := range shallowest {
+				if c.tagged {
+					tagged = append(tagged, c)
+				}
+			}
+			if len(tagged) > 0 {
+				shallowest = tagged
+			}
+		}
+		if len(shallowest) == 1 {
+			dominant[name] = shallowest[0]
+		}
+	}
+	result := make([]*Field, 0, len(dominant))
+	for _, c := range candidates {
+		if dominant[c.name] == c && !c.embed {
+			result = append(result, c.field)
+		}
+	}
+	return result
+}
+
+// promoteFields 按 Go 的选择器规则计算结构的所有可访问字段
+// 已展开的嵌入结构本身不再出现在结果中, 其字段按层级提升; 嵌入的接口保留为字段
+func promoteFields(declared []*Field) []*Field {
+	candidates := make([]*fieldCandidate, 0, len(declared))
+	collectFields(declared, 0, "", make(map[string]bool), "", &candidates)
+	return selectFields(candidates, "")
+}
+
+// JSONField 按 encoding/json 的规则计算结构序列化时的字段
+// 带json名称的嵌入结构视为普通字段, 忽略 `json:"-"` 和非公开字段, 同名时层级最浅(其次带标签)的字段胜出
+func (s *Struct) JSONField() []*Field {
+	return s.wireFields(constants.CodecJSON)
+}
+
+// wireFields 按编码的规则计算结构序列化时的字段
+func (s *Struct) wireFields(codec constants.Codec) []*Field {
+	if s == nil {
+		return nil
+	}
+	fields := s.Declared
+	if fields == nil {
+		fields = s.Field
+	}
+	candidates := make([]*fieldCandidate, 0, len(fields))
+	collectFields(fields, 0, "", make(map[string]bool), codec, &candidates)
+	return selectFields(candidates, codec)
+}
+
+// promoteMethods 按 Go 的方法提升规则合并本结构的方法与嵌入结构提升上来的方法
+// 本结构的方法遮蔽嵌入结构中的同名方法(标记为 Override), 提升的方法中层级最浅的胜出, 同层级同名的都不提升
+func promoteMethods(own []*Function, promoted []*Function) []*Function {
+	result := make([]*Function, 0, len(own)+len(promoted))
+	names := make(map[string]bool, len(own))
+	for _, method := range own {
+		names[method.Name] = true
+	}
+	shallowest := make(map[string]int)
+	count := make(map[string]int)
+	for _, method := range promoted {
+		if names[method.Name] {
+			continue
+		}
+		depth, ok := shallowest[method.Name]
+		switch {
+		case !ok || method.Depth < depth:
+			shallowest[method.Name] = method.Depth
+			count[method.Name] = 1
+		case method.Depth == depth:
+			count[method.Name]++
+		}
+	}
+	for _, method := range own {
+		method.Override = slices.ContainsFunc(promoted, func(f *Function) bool {
+			return f.Name == method.Name
+		})
+		result = append(result, method)
+	}
+	for _, method := range promoted {
+		if !names[method.Name] && count[method.Name] == 1 && shallowest[method.Name] == method.Depth {
+			result = append(result, method)
+		}
+	}
+	return result
+}
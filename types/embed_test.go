@@ -0,0 +1,90 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"testing"
+)
+
+func embedField(s *Struct, pointer bool, tag string) *Field {
+	return &Field{Name: constants.EmptyName, Type: s.Type, TypeName: s.TypeName, Parent: true, Pointer: pointer, Tag: tag, Struct: s}
+}
+
+func fieldNames(fields []*Field) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Embed+":"+f.Name)
+	}
+	return names
+}
+
+func Test_promoteFields(t *testing.T) {
+	// type Audit struct { ID int64; By string }
+	// type Base  struct { ID int64; Name string; Audit }
+	// type Extra struct { Name string; Note string }
+	// type User  struct { *Base; Extra; Email string; Logger }
+	audit := &Struct{Name: "Audit", Type: "Audit", TypeName: "Audit", Field: []*Field{
+		{Name: "ID", Type: "int64"}, {Name: "By", Type: "string"},
+	}}
+	base := &Struct{Name: "Base", Type: "Base", TypeName: "Base"}
+	base.Declared = []*Field{{Name: "ID", Type: "int64"}, {Name: "Name", Type: "string"}, embedField(audit, false, "")}
+	base.Field = promoteFields(base.Declared)
+	extra := &Struct{Name: "Extra", Type: "Extra", TypeName: "Extra", Field: []*Field{
+		{Name: "Name", Type: "string", Tag: "`json:\"name\"`"}, {Name: "Note", Type: "string", Tag: "`json:\"-\"`"},
+	}}
+	logger := &Struct{Name: "Logger", Type: "Logger", TypeName: "Logger", ElemType: constants.ElemInterface}
+	declared := []*Field{
+		embedField(base, true, ""),
+		embedField(extra, false, ""),
+		{Name: "Email", Type: "string"},
+		embedField(logger, false, ""),
+	}
+
+	// Base.ID 遮蔽 Audit.ID, Base.Name 与 Extra.Name 同层级冲突
+	got := fieldNames(promoteFields(declared))
+	want := []string{"Base:ID", "Base.Audit:By", "Extra:Note", ":Email", ":_"}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatal(got)
+		}
+	}
+	if base.Field[2].Depth != 1 {
+		t.Fatal(base.Field[2].Depth)
+	}
+
+	// json: Extra.Name 的标签名称不同, 不再冲突; Note 被忽略; 嵌入的接口以类型名作为字段名
+	s := &Struct{Declared: declared}
+	got = nil
+	for _, f := range s.JSONField() {
+		got = append(got, f.JSONName())
+	}
+	want = []string{"ID", "Name", "By", "name", "Email", "Logger"}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatal(got)
+		}
+	}
+}
+
+func Test_promoteMethods(t *testing.T) {
+	own := []*Function{{Name: "List"}, {Name: "Me"}}
+	promoted := []*Function{
+		{Name: "List", Depth: 1, Embed: "BaseCtl"},
+		{Name: "Get", Depth: 1, Embed: "BaseCtl"},
+		{Name: "Get", Depth: 2, Embed: "BaseCtl.IdCtl"},
+		{Name: "Detail", Depth: 1, Embed: "BaseCtl"},
+		{Name: "Detail", Depth: 1, Embed: "LogCtl"},
+	}
+	got := promoteMethods(own, promoted)
+	if len(got) != 3 || got[0].Name != "List" || !got[0].Override || got[1].Override {
+		t.Fatalf("methods: %v", got)
+	}
+	if got[2].Name != "Get" || got[2].Embed != "BaseCtl" {
+		t.Fatal(got[2].Embed)
+	}
+}
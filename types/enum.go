@@ -13,6 +13,10 @@ type Enum struct {
 	Private  bool               `json:"private,omitempty"`
 	Iota     bool               `json:"iota"`
 	Enums    []*EnumItem        `json:"enums,omitempty"`
+	// 枚举类型实现的方法, 决定了其文本/序列化后的表示
+	Stringer      bool `json:"stringer,omitempty"`       // String() string
+	TextMarshaler bool `json:"text_marshaler,omitempty"` // MarshalText() ([]byte, error)
+	JSONMarshaler bool `json:"json_marshaler,omitempty"` // MarshalJSON() ([]byte, error)
 }
 
 func (e *Enum) String() string {
@@ -34,6 +38,10 @@ func (e *Enum) Clone() *Enum {
 		Private:  e.Private,
 		Iota:     e.Iota,
 		Enums:    CopySlice(e.Enums),
+
+		Stringer:      e.Stringer,
+		TextMarshaler: e.TextMarshaler,
+		JSONMarshaler: e.JSONMarshaler,
 	}
 }
 
@@ -42,6 +50,9 @@ type EnumItem struct {
 	Name    string     `json:"name"`
 	Type    string     `json:"type"`
 	Value   any        `json:"value"`
+	Exact   string     `json:"exact,omitempty"`
+	Package *Package   `json:"package,omitempty"` // 声明在类型所在包以外时, 枚举项所在的包
+	Label   string     `json:"label,omitempty"`   // 显示名称(文本/序列化后的表示) eg. String() 的返回值
 	Private bool       `json:"private"`
 	Doc     []*Comment `json:"doc,omitempty"`
 	Comment []*Comment `json:"comment,omitempty"`
@@ -60,8 +71,38 @@ func (e *EnumItem) Clone() *EnumItem {
 		Name:    e.Name,
 		Type:    e.Type,
 		Value:   e.Value,
+		Exact:   e.Exact,
+		Package: e.Package.Clone(),
+		Label:   e.Label,
 		Private: e.Private,
 		Doc:     CopySlice(e.Doc),
 		Comment: CopySlice(e.Comment),
 	}
 }
+
+var _ IElem[*EnumLabel] = (*EnumLabel)(nil)
+
+// EnumLabel 可静态得出的枚举项显示名称
+// 来自枚举类型的 String/MarshalText/MarshalJSON 方法中的 switch, 或包中的 map[T]string 字面量
+type EnumLabel struct {
+	Type   string `json:"type"`   // 枚举类型 eg. Status
+	Name   string `json:"name"`   // 枚举项 eg. Paid, 为空时仅表示类型实现了 Source 方法
+	Label  string `json:"label"`  // 显示名称 eg. paid
+	Source string `json:"source"` // 来源 方法名或变量名 eg. String / statusNames
+}
+
+func (e *EnumLabel) String() string {
+	return e.Name + "(" + e.Label + ")"
+}
+
+func (e *EnumLabel) Clone() *EnumLabel {
+	if e == nil {
+		return nil
+	}
+	return &EnumLabel{
+		Type:   e.Type,
+		Name:   e.Name,
+		Label:  e.Label,
+		Source: e.Source,
+	}
+}
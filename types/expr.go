@@ -0,0 +1,56 @@
+package types
+
+import "github.com/linxlib/astp/constants"
+
+var _ IElem[*Expr] = (*Expr)(nil)
+
+// Expr 变量初始化表达式
+type Expr struct {
+	Kind     constants.ExprKind `json:"kind"`
+	Text     string             `json:"text"`                // 表达式源码
+	Key      string             `json:"key,omitempty"`       // 作为复合字面量的元素时的键(字段名/map的键)
+	Value    any                `json:"value,omitempty"`     // 字面量的值
+	Name     string             `json:"name,omitempty"`      // 引用的标识符/被调用的函数 eg. New / DefaultConfig
+	Op       string             `json:"op,omitempty"`        // 一元/二元运算符
+	Type     string             `json:"type,omitempty"`      // 复合字面量的类型 eg. Config
+	TypeName string             `json:"type_name,omitempty"` // eg. config.Config / []int
+	Package  *Package           `json:"package,omitempty"`   // 引用的包 eg. errors.New 中的 errors
+	Args     []*Expr            `json:"args,omitempty"`      // 函数调用的参数 / 一元二元运算的操作数
+	Elts     []*Expr            `json:"elts,omitempty"`      // 复合字面量的元素
+}
+
+func (e *Expr) String() string {
+	return e.Text
+}
+
+func (e *Expr) Clone() *Expr {
+	if e == nil {
+		return nil
+	}
+	return &Expr{
+		Kind:     e.Kind,
+		Text:     e.Text,
+		Key:      e.Key,
+		Value:    e.Value,
+		Name:     e.Name,
+		Op:       e.Op,
+		Type:     e.Type,
+		TypeName: e.TypeName,
+		Package:  e.Package.Clone(),
+		Args:     CopySlice(e.Args),
+		Elts:     CopySlice(e.Elts),
+	}
+}
+
+// Field 复合字面量中键为 name 的元素 eg. Config{Port: 8080} 中的 Port
+func (e *Expr) Field(name string) *Expr {
+	if e == nil {
+		return nil
+	}
+	for _, elt := range e.Elts {
+		if elt.Key == name {
+			return elt
+		}
+	}
+	return nil
+}
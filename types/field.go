@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/linxlib/astp/constants"
 	"reflect"
 	"strings"
 )
@@ -8,21 +9,30 @@ import (
 var _ IElem[*Field] = (*Field)(nil)
 
 type Field struct {
-	Index     int          `json:"index"`
-	Name      string       `json:"name"`
-	TypeName  string       `json:"type_name"`
-	Type      string       `json:"type"`
-	Parent    bool         `json:"parent,omitempty"`
-	Private   bool         `json:"private,omitempty"`
-	Generic   bool         `json:"generic,omitempty"`
-	Slice     bool         `json:"slice,omitempty"`
-	Pointer   bool         `json:"pointer,omitempty"`
-	TypeParam []*TypeParam `json:"type_param,omitempty"`
-	Tag       string       `json:"tag,omitempty"`
-	Doc       []*Comment   `json:"doc,omitempty"`
-	Comment   []*Comment   `json:"comment,omitempty"`
-	Struct    *Struct      `json:"struct,omitempty"`
-	Package   *Package     `json:"package,omitempty"`
+	Index     int               `json:"index"`
+	Name      string            `json:"name"`
+	TypeName  string            `json:"type_name"`
+	Type      string            `json:"type"`
+	Parent    bool              `json:"parent,omitempty"`
+	Private   bool              `json:"private,omitempty"`
+	Generic   bool              `json:"generic,omitempty"`
+	Slice     bool              `json:"slice,omitempty"`
+	Pointer   bool              `json:"pointer,omitempty"`
+	Chan      bool              `json:"chan,omitempty"`
+	ChanDir   constants.ChanDir `json:"chan_dir,omitempty"`
+	TypeParam []*TypeParam      `json:"type_param,omitempty"`
+	Tag       string            `json:"tag,omitempty"`
+	Tags      []*Tag            `json:"tags,omitempty"`  // 解析后的标签
+	Rules     []*Rule           `json:"rules,omitempty"` // validate/binding 标签中的校验规则
+	Doc       []*Comment        `json:"doc,omitempty"`
+	Comment   []*Comment        `json:"comment,omitempty"`
+	Struct    *Struct           `json:"struct,omitempty"`
+	Package   *Package          `json:"package,omitempty"`
+	Depth     int               `json:"depth,omitempty"`     // 提升字段所在的嵌入层级, 本结构声明的字段为0
+	Embed     string            `json:"embed,omitempty"`     // 提升字段经过的嵌入字段 eg. CrudCtl.IdCtl
+	Recursive bool              `json:"recursive,omitempty"` // 字段类型引用回了所在结构(自引用/相互引用), Struct 仅为引用, 不含字段
+	Enum      *Enum             `json:"enum,omitempty"`      // 类型为枚举时, 对应的枚举定义
+	Pos       string            `json:"pos,omitempty"`       // 声明位置 eg. models/user.go:12
 }
 
 func (f *Field) IsTop() bool {
@@ -46,12 +56,21 @@ func (f *Field) Clone() *Field {
 		Generic:   f.Generic,
 		Pointer:   f.Pointer,
 		Slice:     f.Slice,
+		Chan:      f.Chan,
+		ChanDir:   f.ChanDir,
 		TypeParam: CopySlice(f.TypeParam),
 		Tag:       f.Tag,
+		Tags:      CopySlice(f.Tags),
+		Rules:     CopySlice(f.Rules),
 		Package:   f.Package.Clone(),
 		Doc:       f.Doc,
 		Comment:   f.Comment,
 		Struct:    f.Struct.Clone(),
+		Depth:     f.Depth,
+		Embed:     f.Embed,
+		Recursive: f.Recursive,
+		Enum:      f.Enum.Clone(),
+		Pos:       f.Pos,
 	}
 }
 func (f *Field) HasTag() bool {
@@ -68,3 +87,49 @@ func (f *Field) GetTagByName(name string) string {
 		return ""
 	}
 }
+
+// TagOf 返回解析后的某个标签, 没有时返回nil
+func (f *Field) TagOf(key string) *Tag {
+	tags := f.Tags
+	if tags == nil && f.Tag != "" {
+		tags, _ = ParseTag(f.Tag)
+	}
+	for _, tag := range tags {
+		if tag.Key == key {
+			return tag
+		}
+	}
+	return nil
+}
+
+func (f *Field) JSONTag() *Tag   { return f.TagOf("json") }
+func (f *Field) XMLTag() *Tag    { return f.TagOf("xml") }
+func (f *Field) YAMLTag() *Tag   { return f.TagOf("yaml") }
+func (f *Field) FormTag() *Tag   { return f.TagOf("form") }
+func (f *Field) QueryTag() *Tag  { return f.TagOf("query") }
+func (f *Field) HeaderTag() *Tag { return f.TagOf("header") }
+func (f *Field) URITag() *Tag    { return f.TagOf("uri") }
+func (f *Field) GormTag() *Tag   { return f.TagOf("gorm") }
+
+// ValidateTag 校验规则标签, validate 优先, 其次为 gin 的 binding
+func (f *Field) ValidateTag() *Tag {
+	if tag := f.TagOf("validate"); tag != nil {
+		return tag
+	}
+	return f.TagOf("binding")
+}
+
+// RuleOf 返回作用于字段本身(不是 dive 之后)的某条校验规则, 没有时返回nil eg. RuleOf("max")
+func (f *Field) RuleOf(name string) *Rule {
+	for _, r := range f.Rules {
+		if r.Name == name && !r.Elem {
+			return r
+		}
+	}
+	return nil
+}
+
+// Required 字段是否为必填
+func (f *Field) Required() bool {
+	return f.RuleOf("required") != nil
+}
@@ -3,17 +3,20 @@ package types
 var _ IElem[*File] = (*File)(nil)
 
 type File struct {
-	Name      string       `json:"name"`
-	Key       string       `json:"-"`
-	KeyHash   string       `json:"-"`
-	Package   *Package     `json:"package,omitempty"`
-	Comment   []*Comment   `json:"comment,omitempty"`
-	Import    []*Import    `json:"import,omitempty"`
-	Variable  []*Variable  `json:"variable,omitempty"`
-	Const     []*Const     `json:"const,omitempty"`
-	Function  []*Function  `json:"function,omitempty"`
-	Interface []*Interface `json:"interface,omitempty"`
-	Struct    []*Struct    `json:"struct,omitempty"`
+	Name       string          `json:"name"`
+	Key        string          `json:"-"`
+	KeyHash    string          `json:"-"`
+	Package    *Package        `json:"package,omitempty"`
+	Comment    []*Comment      `json:"comment,omitempty"`
+	Import     []*Import       `json:"import,omitempty"`
+	Variable   []*Variable     `json:"variable,omitempty"`
+	Const      []*Const        `json:"const,omitempty"`
+	Function   []*Function     `json:"function,omitempty"`
+	Interface  []*Interface    `json:"interface,omitempty"`
+	Struct     []*Struct       `json:"struct,omitempty"`
+	Instance   []*FuncInstance `json:"instance,omitempty"`   // 文件中对泛型函数的显式实例化
+	EnumLabel  []*EnumLabel    `json:"enum_label,omitempty"` // 文件中可静态得出的枚举项显示名称
+	Diagnostic []*Diagnostic   `json:"diagnostic,omitempty"` // 解析过程中发现的问题 eg. 无效的结构体标签
 }
 
 func (f *File) String() string {
@@ -25,17 +28,20 @@ func (f *File) Clone() *File {
 		return nil
 	}
 	return &File{
-		Name:      f.Name,
-		Key:       f.Key,
-		KeyHash:   f.KeyHash,
-		Package:   f.Package,
-		Comment:   f.Comment,
-		Import:    f.Import,
-		Variable:  CopySlice(f.Variable),
-		Const:     CopySlice(f.Const),
-		Function:  CopySlice(f.Function),
-		Interface: CopySlice(f.Interface),
-		Struct:    CopySlice(f.Struct),
+		Name:       f.Name,
+		Key:        f.Key,
+		KeyHash:    f.KeyHash,
+		Package:    f.Package,
+		Comment:    f.Comment,
+		Import:     f.Import,
+		Variable:   CopySlice(f.Variable),
+		Const:      CopySlice(f.Const),
+		Function:   CopySlice(f.Function),
+		Interface:  CopySlice(f.Interface),
+		Struct:     CopySlice(f.Struct),
+		Instance:   CopySlice(f.Instance),
+		EnumLabel:  CopySlice(f.EnumLabel),
+		Diagnostic: CopySlice(f.Diagnostic),
 	}
 }
 
@@ -18,10 +18,17 @@ type Function struct {
 	Index     int                `json:"index"`
 	Package   *Package           `json:"package,omitempty"`
 	Generic   bool               `json:"generic,omitempty"`
+	Variadic  bool               `json:"variadic,omitempty"`
 	TypeParam []*TypeParam       `json:"type_param,omitempty"`
 	Param     []*Param           `json:"param,omitempty"`
 	Result    []*Param           `json:"result,omitempty"`
 	Receiver  *Receiver          `json:"receiver,omitempty"`
+	Instance  []*FuncInstance    `json:"instance,omitempty"` // 泛型函数在项目中的显式实例化
+	Depth     int                `json:"depth,omitempty"`    // 提升方法所在的嵌入层级, 本结构声明的方法为0
+	Embed     string             `json:"embed,omitempty"`    // 提升方法经过的嵌入字段 eg. CrudCtl.IdCtl
+	Origin    *Receiver          `json:"origin,omitempty"`   // 提升方法原本的接收器(声明该方法的结构)
+	Override  bool               `json:"override,omitempty"` // 本结构的方法遮蔽了嵌入结构中的同名方法
+	Pos       string             `json:"pos,omitempty"`      // 声明位置 eg. models/user.go:12
 	rValue    reflect.Value
 	value     any
 }
@@ -38,6 +45,14 @@ func (f *Function) IsOp() bool {
 	return false
 }
 
+// IsVariadic 最后一个参数是否为可变参数 eg. func(a ...string)
+func (f *Function) IsVariadic() bool {
+	if len(f.Param) == 0 {
+		return false
+	}
+	return f.Param[len(f.Param)-1].Variadic
+}
+
 func (f *Function) String() string {
 	return f.Name
 }
@@ -54,6 +69,7 @@ func (f *Function) Clone() *Function {
 		TypeName:  f.TypeName,
 		Doc:       CopySlice(f.Doc),
 		Generic:   f.Generic,
+		Variadic:  f.Variadic,
 		Private:   f.Private,
 		Index:     f.Index,
 		Package:   f.Package.Clone(),
@@ -61,6 +77,12 @@ func (f *Function) Clone() *Function {
 		Param:     CopySlice(f.Param),
 		Result:    CopySlice(f.Result),
 		Receiver:  f.Receiver.Clone(),
+		Instance:  CopySlice(f.Instance),
+		Depth:     f.Depth,
+		Embed:     f.Embed,
+		Origin:    f.Origin.Clone(),
+		Override:  f.Override,
+		Pos:       f.Pos,
 	}
 }
 
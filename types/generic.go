@@ -0,0 +1,309 @@
+package types
+
+import (
+	"strings"
+)
+
+// Instantiator 泛型实例化
+// 持有 泛型参数名(T/E...) -> 实际类型 的映射, 将其代入字段/参数/返回值/方法中,
+// 并对其中引用到的泛型结构逐层实例化 eg. Resp[PageResult[[]*User]]
+type Instantiator struct {
+	args map[string]*TypeParam
+	// 正在实例化的结构(以实例化后的TypeName为key), 防止自引用的泛型结构无限递归
+	visiting map[string]bool
+}
+
+// NewInstantiator 按索引将泛型定义 params 与实际类型 args 一一对应
+// params/args 均可为空, 此时仅对其中的泛型结构进行实例化
+func NewInstantiator(params []*TypeParam, args []*TypeParam) *Instantiator {
+	return newInstantiator(params, args, make(map[string]bool))
+}
+
+func newInstantiator(params []*TypeParam, args []*TypeParam, visiting map[string]bool) *Instantiator {
+	in := &Instantiator{
+		args:     make(map[string]*TypeParam),
+		visiting: visiting,
+	}
+	for i, param := range params {
+		if i >= len(args) || args[i] == nil {
+			continue
+		}
+		arg := args[i]
+		if arg.Struct != nil && arg.Struct.Generic && len(arg.TypeParam) > 0 {
+			// 实参本身也是泛型结构 eg. PageResult[*User], 先将其实例化
+			arg = arg.Clone()
+			arg.Struct = in.Struct(arg.Struct, arg.TypeParam)
+		}
+		in.args[param.Type] = arg
+	}
+	return in
+}
+
+// Instantiate 使用实际类型 args 实例化泛型结构, 返回一个新的结构(包含方法)
+// 非泛型结构直接返回其拷贝
+func (s *Struct) Instantiate(args []*TypeParam) *Struct {
+	return NewInstantiator(nil, nil).Struct(s, args)
+}
+
+// Struct 实例化泛型结构 s
+func (in *Instantiator) Struct(s *Struct, args []*TypeParam) *Struct {
+	if s == nil {
+		return nil
+	}
+	if !s.Generic || len(s.TypeParam) == 0 || len(args) == 0 {
+		return s.CloneFull()
+	}
+	typeName := baseTypeName(s.TypeName) + "[" + joinTypeNames(args) + "]"
+	result := s.CloneFull()
+	result.TypeName = typeName
+	result.Generic = false
+	result.TypeParam = make([]*TypeParam, 0, len(s.TypeParam))
+	for i, tp := range s.TypeParam {
+		if i >= len(args) {
+			break
+		}
+		arg := args[i].Clone()
+		arg.Index = tp.Index
+		arg.Key = tp.Key
+		arg.OType = tp.Type
+		result.TypeParam = append(result.TypeParam, arg)
+	}
+	if in.visiting[typeName] {
+		// 自引用 eg. Node[T]{ Children []*Node[T] }, 不再展开
+		result.Field = nil
+		result.Method = nil
+		return result
+	}
+	in.visiting[typeName] = true
+	defer delete(in.visiting, typeName)
+
+	child := newInstantiator(s.TypeParam, args, in.visiting)
+	for _, field := range append(result.Field, result.Declared...) {
+		child.Field(field)
+	}
+	for _, method := range result.Method {
+		child.Function(method)
+	}
+	return result
+}
+
+// IsInstance 是否为泛型结构实例化后的结构
+func (s *Struct) IsInstance() bool {
+	return s != nil && !s.Generic && len(s.TypeParam) > 0 && s.TypeParam[0].OType != ""
+}
+
+// substitute 将实际类型代入一个已实例化的结构
+// 其字段中仍引用着外层的泛型参数(与当前映射中的泛型参数同名), 直接对字段和方法进行代入即可
+func (in *Instantiator) substitute(s *Struct, args []*TypeParam) *Struct {
+	typeName := baseTypeName(s.TypeName) + "[" + joinTypeNames(args) + "]"
+	result := s.CloneFull()
+	result.TypeName = typeName
+	for i, tp := range result.TypeParam {
+		if i >= len(args) {
+			break
+		}
+		arg := args[i].Clone()
+		arg.Index = tp.Index
+		arg.Key = tp.Key
+		arg.OType = tp.OType
+		result.TypeParam[i] = arg
+	}
+	if in.visiting[typeName] {
+		result.Field = nil
+		result.Method = nil
+		return result
+	}
+	in.visiting[typeName] = true
+	defer delete(in.visiting, typeName)
+	for _, field := range append(result.Field, result.Declared...) {
+		in.Field(field)
+	}
+	for _, method := range result.Method {
+		in.Function(method)
+	}
+	return result
+}
+
+// Field 将实际类型代入字段
+func (in *Instantiator) Field(f *Field) {
+	if f == nil {
+		return
+	}
+	in.apply(typeRef{
+		Type:      &f.Type,
+		TypeName:  &f.TypeName,
+		Pointer:   &f.Pointer,
+		Slice:     &f.Slice,
+		Generic:   &f.Generic,
+		TypeParam: &f.TypeParam,
+		Struct:    &f.Struct,
+		Package:   &f.Package,
+		Enum:      &f.Enum,
+	})
+	if f.Struct != nil && in.visiting[f.Struct.TypeName] {
+		// 自引用的泛型结构, 其Struct未展开
+		f.Recursive = true
+	}
+}
+
+// Param 将实际类型代入参数/返回值
+func (in *Instantiator) Param(p *Param) {
+	if p == nil {
+		return
+	}
+	in.apply(typeRef{
+		Type:      &p.Type,
+		TypeName:  &p.TypeName,
+		Pointer:   &p.Pointer,
+		Slice:     &p.Slice,
+		Generic:   &p.Generic,
+		TypeParam: &p.TypeParam,
+		Struct:    &p.Struct,
+		Package:   &p.Package,
+		Enum:      &p.Enum,
+	})
+}
+
+// TypeParam 将实际类型代入类型参数(实参)
+func (in *Instantiator) TypeParam(t *TypeParam) {
+	if t == nil {
+		return
+	}
+	generic := len(t.TypeParam) > 0
+	in.apply(typeRef{
+		Type:      &t.Type,
+		TypeName:  &t.TypeName,
+		Pointer:   &t.Pointer,
+		Slice:     &t.Slice,
+		Generic:   &generic,
+		TypeParam: &t.TypeParam,
+		Struct:    &t.Struct,
+		Package:   &t.Package,
+		Enum:      &t.Enum,
+	})
+}
+
+// Function 将实际类型代入方法的接收器/参数/返回值
+func (in *Instantiator) Function(f *Function) {
+	if f == nil {
+		return
+	}
+	in.Receiver(f.Receiver)
+	in.Receiver(f.Origin)
+	for _, param := range f.Param {
+		in.Param(param)
+	}
+	for _, result := range f.Result {
+		in.Param(result)
+	}
+}
+
+// Receiver 将实际类型代入接收器 eg. *BaseCtl[E] -> *BaseCtl[User]
+func (in *Instantiator) Receiver(r *Receiver) {
+	if r == nil || len(r.TypeParam) == 0 {
+		return
+	}
+	for _, tp := range r.TypeParam {
+		in.TypeParam(tp)
+	}
+	prefix, base := splitTypeName(r.TypeName)
+	r.TypeName = prefix + base + "[" + joinTypeNames(r.TypeParam) + "]"
+}
+
+// typeRef 字段/参数/类型参数中描述"引用了哪个类型"的部分
+type typeRef struct {
+	Type      *string
+	TypeName  *string
+	Pointer   *bool
+	Slice     *bool
+	Generic   *bool
+	TypeParam *[]*TypeParam
+	Struct    **Struct
+	Package   **Package
+	Enum      **Enum
+}
+
+// isTypeParamRef 是否直接引用了泛型参数 eg. T / *T / []*T
+// 对于泛型参数的引用, 其TypeParam中(若有)只有它自己
+func (r typeRef) isTypeParamRef() bool {
+	for _, tp := range *r.TypeParam {
+		if tp.Type != *r.Type {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *Instantiator) apply(r typeRef) {
+	if arg, ok := in.args[*r.Type]; ok && r.isTypeParamRef() {
+		// T -> 实际类型
+		prefix, _ := splitTypeName(*r.TypeName)
+		*r.Type = arg.Type
+		*r.TypeName = prefix + arg.TypeName
+		*r.Pointer = *r.Pointer || arg.Pointer
+		*r.Slice = *r.Slice || arg.Slice
+		*r.Generic = len(arg.TypeParam) > 0
+		*r.TypeParam = CopySlice(arg.TypeParam)
+		*r.Struct = arg.Struct.CloneFull()
+		*r.Package = arg.Package.Clone()
+		*r.Enum = arg.Enum.Clone()
+		return
+	}
+	if len(*r.TypeParam) == 0 {
+		return
+	}
+	// Resp[T] / Resp[PageResult[T]]: 先代入类型实参, 再实例化对应的泛型结构
+	for _, tp := range *r.TypeParam {
+		in.TypeParam(tp)
+	}
+	if s := *r.Struct; s != nil {
+		if s.Generic {
+			*r.Struct = in.Struct(s, *r.TypeParam)
+		} else if s.IsInstance() && len(in.args) > 0 {
+			// 已用外层泛型参数实例化过的结构 eg. 上级结构 CrudCtl[T, ID] 中的 Resp[T]
+			*r.Struct = in.substitute(s, *r.TypeParam)
+		}
+	}
+	prefix, base := splitTypeName(*r.TypeName)
+	*r.TypeName = prefix + base + "[" + joinTypeNames(*r.TypeParam) + "]"
+}
+
+// splitTypeName 将类型名拆为修饰前缀与基础类型名
+// eg. []*resp.Resp[T] -> "[]*", "resp.Resp"
+func splitTypeName(typeName string) (prefix string, base string) {
+	rest := typeName
+	for {
+		switch {
+		case strings.HasPrefix(rest, "*"):
+			prefix += "*"
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "[]"):
+			prefix += "[]"
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "<-chan "), strings.HasPrefix(rest, "chan<- "):
+			prefix += rest[:7]
+			rest = rest[7:]
+		case strings.HasPrefix(rest, "chan "):
+			prefix += "chan "
+			rest = rest[5:]
+		default:
+			return prefix, baseTypeName(rest)
+		}
+	}
+}
+
+// baseTypeName 去掉类型实参部分 eg. resp.Resp[T] -> resp.Resp
+func baseTypeName(typeName string) string {
+	if idx := strings.Index(typeName, "["); idx > 0 {
+		return typeName[:idx]
+	}
+	return typeName
+}
+
+func joinTypeNames(tps []*TypeParam) string {
+	names := make([]string, 0, len(tps))
+	for _, tp := range tps {
+		names = append(names, tp.TypeName)
+	}
+	return strings.Join(names, ",")
+}
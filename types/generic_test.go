@@ -0,0 +1,126 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"testing"
+)
+
+func genericStruct(name string, field string, fieldTypeName string) *Struct {
+	return &Struct{
+		Name:     name,
+		Type:     name,
+		TypeName: "resp." + name,
+		Generic:  true,
+		TypeParam: []*TypeParam{
+			{Type: "T", TypeName: "T", ElemType: constants.ElemGeneric},
+		},
+		Field: []*Field{
+			{Name: "Code", Type: "int", TypeName: "int"},
+			{
+				Name:      field,
+				Type:      "T",
+				TypeName:  fieldTypeName,
+				Generic:   true,
+				Slice:     fieldTypeName == "[]T",
+				TypeParam: []*TypeParam{{Type: "T", TypeName: "T"}},
+			},
+		},
+		Package: &Package{Name: "resp", Path: "demo/resp"},
+	}
+}
+
+func Test_Instantiate(t *testing.T) {
+	user := &Struct{Name: "User", Type: "User", TypeName: "models.User"}
+	resp := genericStruct("Resp", "Data", "T")
+	page := genericStruct("PageResult", "List", "[]T")
+
+	// Resp[PageResult[*models.User]]
+	arg := &TypeParam{
+		Type:     "PageResult",
+		TypeName: "resp.PageResult[*models.User]",
+		Struct:   page,
+		TypeParam: []*TypeParam{
+			{Type: "User", TypeName: "*models.User", Pointer: true, Struct: user},
+		},
+	}
+	s := resp.Instantiate([]*TypeParam{arg})
+	if s.TypeName != "resp.Resp[resp.PageResult[*models.User]]" || s.Generic {
+		t.Fatal(s.TypeName)
+	}
+	data := s.Field[1]
+	if data.Type != "PageResult" || data.Struct == nil || data.Struct.TypeName != "resp.PageResult[*models.User]" {
+		t.Fatal(data.TypeName)
+	}
+	list := data.Struct.Field[1]
+	if list.TypeName != "[]*models.User" || !list.Slice || !list.Pointer || list.Struct == nil || list.Struct.Name != "User" {
+		t.Fatal(list.TypeName)
+	}
+	// 原结构不受影响
+	if resp.Field[1].TypeName != "T" || page.Field[1].Struct != nil {
+		t.Fatalf("Resp.Data: %s, PageResult.List: %v", resp.Field[1].TypeName, page.Field[1].Struct)
+	}
+}
+
+func Test_InstantiateParam(t *testing.T) {
+	resp := genericStruct("Resp", "Data", "T")
+	// func (b *BaseCtl[E]) Get() *resp.Resp[[]*E]
+	param := &Param{
+		Type:      "Resp",
+		TypeName:  "*resp.Resp[[]*E]",
+		Pointer:   true,
+		Generic:   true,
+		Struct:    resp,
+		TypeParam: []*TypeParam{{Type: "E", TypeName: "[]*E", Slice: true, Pointer: true}},
+	}
+	params := []*TypeParam{{Type: "E"}}
+	args := []*TypeParam{{Type: "User", TypeName: "User", Struct: &Struct{Name: "User"}}}
+	NewInstantiator(params, args).Param(param)
+	if param.TypeName != "*resp.Resp[[]*User]" {
+		t.Fatal(param.TypeName)
+	}
+	if param.Struct.Field[1].TypeName != "[]*User" || param.Struct.Field[1].Struct == nil {
+		t.Fatal(param.Struct.Field[1].TypeName)
+	}
+}
+
+func Test_InstantiateMultiLevel(t *testing.T) {
+	resp := genericStruct("Resp", "Data", "T")
+	// IdCtl[T, ID] 的方法返回 *resp.Resp[T], 先以上级结构 CrudCtl[T, ID] 的泛型参数实例化
+	param := &Param{
+		Type:      "Resp",
+		TypeName:  "*resp.Resp[T]",
+		Pointer:   true,
+		Generic:   true,
+		Struct:    resp,
+		TypeParam: []*TypeParam{{Type: "T", TypeName: "T"}},
+	}
+	params := []*TypeParam{{Type: "T"}}
+	NewInstantiator(params, []*TypeParam{{Type: "T", TypeName: "T"}}).Param(param)
+	if !param.Struct.IsInstance() {
+		t.Fatalf("struct: %s", param.Struct.TypeName)
+	}
+	// 再以 OrderCtl 中的实际类型实例化
+	args := []*TypeParam{{Type: "User", TypeName: "models.User", Struct: &Struct{Name: "User"}}}
+	NewInstantiator(params, args).Param(param)
+	if param.TypeName != "*resp.Resp[models.User]" || param.Struct.TypeName != "resp.Resp[models.User]" {
+		t.Fatal(param.TypeName, param.Struct.TypeName)
+	}
+	if data := param.Struct.Field[1]; data.TypeName != "models.User" || data.Struct == nil {
+		t.Fatal(data.TypeName)
+	}
+}
+
+func Test_InstantiateRecursive(t *testing.T) {
+	// type Node[T any] struct { Children []*Node[T] }
+	node := &Struct{Name: "Node", Type: "Node", TypeName: "Node", Generic: true,
+		TypeParam: []*TypeParam{{Type: "T", TypeName: "T"}}}
+	node.Field = []*Field{{
+		Name: "Children", Type: "Node", TypeName: "[]*Node[T]", Slice: true, Pointer: true, Generic: true,
+		TypeParam: []*TypeParam{{Type: "T", TypeName: "T"}}, Struct: node.Clone(),
+	}}
+	s := node.Instantiate([]*TypeParam{{Type: "int", TypeName: "int"}})
+	children := s.Field[0]
+	if !children.Recursive || children.TypeName != "[]*Node[int]" || children.Struct.Field != nil {
+		t.Fatal(children.TypeName)
+	}
+}
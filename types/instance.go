@@ -0,0 +1,44 @@
+package types
+
+var _ IElem[*FuncInstance] = (*FuncInstance)(nil)
+
+// FuncInstance 泛型函数的一次显式实例化 eg. Paginate[User](q)
+// 仅记录写明了类型实参的调用, 由编译器推导类型实参的调用无法在语法树中识别
+type FuncInstance struct {
+	Name      string       `json:"name"`      // 被调用的函数名
+	TypeName  string       `json:"type_name"` // 实例化的写法 eg. Paginate[User]
+	Package   *Package     `json:"package,omitempty"`
+	TypeParam []*TypeParam `json:"type_param,omitempty"` // 类型实参
+	Param     []*Param     `json:"param,omitempty"`      // 代入类型实参后的参数
+	Result    []*Param     `json:"result,omitempty"`     // 代入类型实参后的返回值
+	Pos       string       `json:"pos,omitempty"`        // 调用位置 eg. service/user.go:12
+	Caller    *Package     `json:"caller,omitempty"`     // 调用处所在的包
+}
+
+func (f *FuncInstance) String() string {
+	return f.TypeName
+}
+
+func (f *FuncInstance) Clone() *FuncInstance {
+	if f == nil {
+		return nil
+	}
+	return &FuncInstance{
+		Name:      f.Name,
+		TypeName:  f.TypeName,
+		Package:   f.Package.Clone(),
+		TypeParam: CopySlice(f.TypeParam),
+		Param:     CopySlice(f.Param),
+		Result:    CopySlice(f.Result),
+		Pos:       f.Pos,
+		Caller:    f.Caller.Clone(),
+	}
+}
+
+// Key 被调用函数的唯一标识 包路径.函数名
+func (f *FuncInstance) Key() string {
+	if f.Package == nil {
+		return f.Name
+	}
+	return f.Package.Path + "." + f.Name
+}
@@ -0,0 +1,241 @@
+package types
+
+import (
+	"fmt"
+	"github.com/linxlib/astp/constants"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// annotationLinter 按注册表检查注解
+type annotationLinter struct {
+	registry *AnnotationRegistry
+	result   []*Diagnostic
+}
+
+// LintAnnotations 按注册表(为nil时使用默认的 Annotations)检查项目中的注解, 返回诊断信息
+// 检查未注册(或拼写错误)的注解, 标注的位置, 参数是否符合定义, 以及同一元素上重复/冲突的注解
+func (p *Project) LintAnnotations(r *AnnotationRegistry) []*Diagnostic {
+	if r == nil {
+		r = Annotations
+	}
+	l := &annotationLinter{registry: r, result: make([]*Diagnostic, 0)}
+	for _, file := range p.sortedFiles() {
+		for _, s := range file.Struct {
+			target := constants.TargetStruct
+			switch {
+			case s.ElemType == constants.ElemInterface:
+				target = constants.TargetInterface
+			case s.IsEnum():
+				target = constants.TargetEnum
+			}
+			l.lint(s.Name, target, s.Pos, append(slices.Clone(s.Doc), s.Comment...))
+			fields := s.Declared
+			if fields == nil {
+				fields = s.Field
+			}
+			for _, f := range fields {
+				if f.Depth > 0 {
+					continue
+				}
+				l.lint(s.Name+"."+f.SelectorName(), constants.TargetField, f.Pos, append(slices.Clone(f.Doc), f.Comment...))
+			}
+			for _, method := range s.Method {
+				// 提升的方法在声明它的结构中检查
+				if method.Depth > 0 {
+					continue
+				}
+				l.lint(s.Name+"."+method.Name, constants.TargetMethod, method.Pos, method.Doc)
+				l.lintParams(s.Name+"."+method.Name, method)
+			}
+		}
+		for _, f := range file.Function {
+			l.lint(f.Name, constants.TargetFunction, f.Pos, f.Doc)
+			l.lintParams(f.Name, f)
+		}
+	}
+	return l.result
+}
+
+func (l *annotationLinter) report(level constants.DiagLevel, code string, pos string, format string, args ...any) {
+	l.result = append(l.result, &Diagnostic{
+		Level:   level,
+		Code:    code,
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// lint 检查一个元素上的注解
+func (l *annotationLinter) lint(name string, target constants.AttrTarget, pos string, comments []*Comment) {
+	seen := make(map[*AnnotationDef]bool)
+	groups := make(map[string]*AnnotationDef)
+	for _, c := range comments {
+		attr := c.AttrName()
+		// 继承来的注解在声明它的结构中检查
+		if attr == "" || c.Origin != "" {
+			continue
+		}
+		def := l.registry.Lookup(attr)
+		if def == nil {
+			if suggest := l.suggest(attr); suggest != nil {
+				l.report(constants.DiagWarning, "unknown-annotation", pos, "%s: unknown annotation @%s, did you mean %s?", name, attr, suggest)
+			} else {
+				l.report(constants.DiagWarning, "unknown-annotation", pos, "%s: unknown annotation @%s", name, attr)
+			}
+			continue
+		}
+		if !def.CanTarget(target) {
+			l.report(constants.DiagError, "misplaced-annotation", pos, "%s: %s cannot be used on %s", name, def, target)
+		}
+		if seen[def] && !def.Repeatable {
+			l.report(constants.DiagError, "duplicate-annotation", pos, "%s: %s is not repeatable", name, def)
+		}
+		seen[def] = true
+		if def.Group != "" {
+			if other, ok := groups[def.Group]; ok && other != def {
+				l.report(constants.DiagError, "conflicting-annotation", pos, "%s: %s conflicts with %s", name, def, other)
+			} else {
+				groups[def.Group] = def
+			}
+		}
+		for _, msg := range checkAnnotationArgs(def, c) {
+			l.report(constants.DiagError, "invalid-annotation-args", pos, "%s: %s %s", name, def, msg)
+		}
+	}
+}
+
+// lintParams 检查 @Param(name) 指定的参数是否存在(报告在方法的位置), 以及指定给参数的注解(报告在参数的位置)
+func (l *annotationLinter) lintParams(name string, f *Function) {
+	for _, c := range f.Doc {
+		// 不带 (name) 的 @Param 为 swagger 的写法 eg. @Param id path int true "user id"
+		if c.Op && c.AttrType == constants.AT_PARAM && c.Target != "" && !slices.ContainsFunc(f.Param, func(p *Param) bool {
+			return p.Name == c.Target
+		}) {
+			l.report(constants.DiagError, "unknown-param", f.Pos, "%s: @Param(%s) has no such parameter", name, c.Target)
+		}
+	}
+	for _, p := range f.Param {
+		l.lint(name+"("+p.Name+")", constants.TargetParam, p.Pos, p.Doc)
+	}
+}
+
+// suggest 查找与拼写错误的名称最接近的已注册注解 eg. Controler -> @Controller
+func (l *annotationLinter) suggest(attr string) *AnnotationDef {
+	var best *AnnotationDef
+	bestDistance := len(attr)/3 + 1
+	for _, def := range l.registry.defs {
+		for _, name := range append([]string{def.Name}, def.Aliases...) {
+			if d := editDistance(strings.ToUpper(attr), strings.ToUpper(name)); d <= bestDistance && (best == nil || d < bestDistance) {
+				best = def
+				bestDistance = d
+			}
+		}
+	}
+	return best
+}
+
+// checkAnnotationArgs 按定义检查注解的参数, 返回问题的描述
+func checkAnnotationArgs(def *AnnotationDef, c *Comment) []string {
+	result := make([]string, 0)
+	if _, err := ParseAttrArgs(c.InlineValue()); err != nil {
+		result = append(result, err.Error())
+	}
+	if def.Args == nil {
+		return result
+	}
+	positional := make([]*AttrArg, 0, len(c.Args))
+	for _, arg := range c.Args {
+		if arg.Key == "" {
+			positional = append(positional, arg)
+		}
+	}
+	used := 0
+	keywords := make(map[string]bool)
+	for _, ad := range def.Args {
+		if ad.Keyword {
+			keywords[ad.Name] = true
+			value, ok := c.KwArg(ad.Name)
+			if !ok {
+				if ad.Required {
+					result = append(result, fmt.Sprintf("missing argument %s", ad.Name))
+				}
+				continue
+			}
+			if msg := checkAnnotationValue(ad, value); msg != "" {
+				result = append(result, msg)
+			}
+			continue
+		}
+		if used >= len(positional) {
+			if ad.Required {
+				result = append(result, fmt.Sprintf("missing argument %s", ad.Name))
+			}
+			continue
+		}
+		values := positional[used : used+1]
+		if ad.Variadic {
+			values = positional[used:]
+		}
+		for _, arg := range values {
+			if msg := checkAnnotationValue(ad, arg.Value); msg != "" {
+				result = append(result, msg)
+			}
+		}
+		used += len(values)
+	}
+	if used < len(positional) {
+		result = append(result, fmt.Sprintf("has too many arguments: %d, want at most %d", len(positional), used))
+	}
+	if len(keywords) > 0 {
+		for _, arg := range c.Args {
+			if arg.Key != "" && !keywords[arg.Key] {
+				result = append(result, fmt.Sprintf("has unknown argument %s", arg.Key))
+			}
+		}
+	}
+	return result
+}
+
+// checkAnnotationValue 检查参数值的类型和可选值
+func checkAnnotationValue(ad *AnnotationArg, value string) string {
+	var err error
+	switch ad.Type {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Sprintf("argument %s: %q is not a valid %s", ad.Name, value, ad.Type)
+	}
+	if len(ad.Enum) > 0 && !slices.Contains(ad.Enum, value) {
+		return fmt.Sprintf("argument %s: %q is not one of %s", ad.Name, value, strings.Join(ad.Enum, ", "))
+	}
+	return ""
+}
+
+// editDistance 两个字符串的编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
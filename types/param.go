@@ -16,9 +16,15 @@ type Param struct {
 	Type      string             `json:"type"`
 	Slice     bool               `json:"slice,omitempty"`
 	Pointer   bool               `json:"pointer,omitempty"`
+	Variadic  bool               `json:"variadic,omitempty"`
+	Chan      bool               `json:"chan,omitempty"`
+	ChanDir   constants.ChanDir  `json:"chan_dir,omitempty"`
 	Generic   bool               `json:"generic,omitempty"`
 	TypeParam []*TypeParam       `json:"type_param,omitempty"`
 	Struct    *Struct            `json:"struct,omitempty"`
+	Enum      *Enum              `json:"enum,omitempty"` // 类型为枚举时, 对应的枚举定义
+	Doc       []*Comment         `json:"doc,omitempty"`  // 方法注释中 @Param(name) 指定给该参数的注解
+	Pos       string             `json:"pos,omitempty"`  // 声明位置 eg. controllers/user.go:12
 	rType     reflect.Type
 }
 
@@ -40,8 +46,14 @@ func (p *Param) Clone() *Param {
 		Struct:    p.Struct.Clone(),
 		Slice:     p.Slice,
 		Pointer:   p.Pointer,
+		Variadic:  p.Variadic,
+		Chan:      p.Chan,
+		ChanDir:   p.ChanDir,
 		Generic:   p.Generic,
 		TypeParam: CopySlice(p.TypeParam),
+		Enum:      p.Enum.Clone(),
+		Doc:       CopySlice(p.Doc),
+		Pos:       p.Pos,
 	}
 }
 func (p *Param) SetRType(t reflect.Type) {
@@ -22,6 +22,7 @@ type Project struct {
 	Generator  string           `json:"generator,omitempty"`
 	Version    string           `json:"version,omitempty"`
 	FileMap    map[string]*File `json:"file,omitempty"`
+	Annotation []*AnnotationDef `json:"annotation,omitempty"` // 解析时已注册的注解, 方便下游工具了解可用的注解
 }
 
 func (p *Project) AddFile(f *File) {
@@ -100,630 +101,421 @@ func (p *Project) Read(path string) error {
 	return nil
 }
 
+// sortedFiles 按包路径和文件名排序的文件
+func (p *Project) sortedFiles() []*File {
+	files := make([]*File, 0, len(p.FileMap))
+	for _, file := range p.FileMap {
+		files = append(files, file)
+	}
+	slices.SortFunc(files, func(a, b *File) int {
+		if c := strings.Compare(a.Package.Path, b.Package.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return files
+}
+
 // handleEnum 处理枚举
-// 提取文件常量(elemType=enum), 并在当前文件中查找对应结构, 扩充该结构的enum字段
+// 提取常量(elemType=enum), 按常量类型所在的包查找对应结构, 扩充该结构的enum字段
+// 常量可以在类型所在包的任意文件中, 也可以在其他包中 eg. const Paid order.Status = 1
 func (p *Project) handleEnum() {
-	// 将枚举合并当当前文件的结构中去
-	// 这里仅处理单个文件
-	for _, file := range p.FileMap {
-		enums := make(map[string][]*Const)
+	// 按包和文件名排序, 保证枚举项的顺序稳定
+	files := p.sortedFiles()
+	keys := make([]string, 0)
+	enums := make(map[string][]*Const)
+	for _, file := range files {
 		for _, c := range file.Const {
-			if c.ElemType == constants.ElemEnum {
-				enums[c.Type] = append(enums[c.Type], c)
+			if c.ElemType != constants.ElemEnum || c.TypePkgPath == "" {
+				continue
 			}
-		}
-		for k, vs := range enums {
-			for _, s := range file.Struct {
-				if s.Name == k {
-					if s.Enum == nil {
-						s.Enum = new(Enum)
-						s.Enum.Enums = make([]*EnumItem, 0)
-					}
-
-					for idx, v := range vs {
-						s.Enum.Type = s.Type
-						s.Enum.TypeName = s.TypeName
-						s.Enum.Private = s.Private
-						s.Enum.Name = s.Name
-						s.Enum.ElemType = constants.ElemEnum
-						s.Enum.Iota = v.Iota
-						s.Enum.Enums = append(s.Enum.Enums, &EnumItem{
-							Index: idx,
-							Name:  v.Name,
-							Type:  v.Type,
-							Value: v.Value,
-
-							Private: internal.IsPrivate(v.Name),
-							Doc:     CopySlice(v.Doc),
-							Comment: CopySlice(v.Comment),
-						})
-					}
-				}
+			key := internal.GetKeyHash(c.TypePkgPath, c.Type)
+			if _, ok := enums[key]; !ok {
+				keys = append(keys, key)
 			}
+			enums[key] = append(enums[key], c)
 		}
 	}
-}
-
-func (p *Project) AfterParseProj() {
-	// 处理枚举合并(将常量合并到对应结构中, 仅合并同文件)
-	p.handleEnum()
-	for _, file := range p.FileMap {
-		for _, s := range file.Struct {
-			p.handleExistsMethods(s)
-			p.handleAnonymousField(s)
+	for _, key := range keys {
+		s := p.findStruct(key)
+		if s == nil {
+			continue
 		}
-	}
-
-}
-
-func (p *Project) findStruct(keyHash string) *Struct {
-	for _, f := range p.FileMap {
-		if s := f.FindStruct(keyHash); s != nil {
-			return s
+		vs := enums[key]
+		// 类型所在包中声明的常量排在前面
+		slices.SortStableFunc(vs, func(a, b *Const) int {
+			aOther, bOther := a.Package.Path != s.Package.Path, b.Package.Path != s.Package.Path
+			switch {
+			case aOther == bOther:
+				return 0
+			case bOther:
+				return -1
+			}
+			return 1
+		})
+		s.Enum = &Enum{
+			Type:     s.Type,
+			TypeName: s.TypeName,
+			Private:  s.Private,
+			Name:     s.Name,
+			ElemType: constants.ElemEnum,
+			Enums:    make([]*EnumItem, 0, len(vs)),
 		}
+		for idx, v := range vs {
+			s.Enum.Iota = s.Enum.Iota || v.Iota
+			item := &EnumItem{
+				Index: idx,
+				Name:  v.Name,
+				Type:  v.Type,
+				Value: v.Value,
+				Exact: v.Exact,
+
+				Private: internal.IsPrivate(v.Name),
+				Doc:     CopySlice(v.Doc),
+				Comment: CopySlice(v.Comment),
+			}
+			if v.Package.Path != s.Package.Path {
+				// 声明在其他包中的枚举项
+				item.Package = v.Package.Clone()
+			}
+			s.Enum.Enums = append(s.Enum.Enums, item)
+		}
+		handleEnumLabel(s, files)
 	}
-	return nil
 }
 
-func (p *Project) FindStruct(keyHash string) *Struct {
-	return p.findStruct(keyHash)
+// enumLabelPriority 不同来源的显示名称的优先级, 越接近序列化后的表示越优先, 其他(map字面量)为0
+var enumLabelPriority = map[string]int{
+	"MarshalJSON": 3,
+	"MarshalText": 2,
+	"String":      1,
 }
 
-func (p *Project) handleStructCurrentMethod(s *Struct) {
-	for _, method := range s.Method {
-		if !method.IsOp() {
+// handleEnumLabel 记录枚举类型实现的 String/MarshalText/MarshalJSON 方法
+// 并将类型所在包中可静态得出的显示名称关联到对应的枚举项
+func handleEnumLabel(s *Struct, files []*File) {
+	labels := make(map[string]*EnumLabel)
+	for _, file := range files {
+		if file.Package.Path != s.Package.Path {
 			continue
 		}
-		for _, param := range method.Param {
-			if !param.Generic {
+		for _, label := range file.EnumLabel {
+			if label.Type != s.Name {
 				continue
 			}
-			if param.Struct == nil {
-				continue
-			}
-			for _, field := range param.Struct.Field {
-				if !field.Generic {
-					continue
+			if label.Name == "" {
+				switch label.Source {
+				case "String":
+					s.Enum.Stringer = true
+				case "MarshalText":
+					s.Enum.TextMarshaler = true
+				case "MarshalJSON":
+					s.Enum.JSONMarshaler = true
 				}
-				for _, tp := range param.Struct.TypeParam {
-					if tp.Type != field.Type {
-						continue
-					}
-					field.Parent = true
-					for _, tp1 := range param.TypeParam {
-						if tp1.Index != tp.Index {
-							continue
-						}
-						if field.TypeParam == nil {
-							field.TypeParam = make([]*TypeParam, 0)
-						}
-						field.TypeParam = append(field.TypeParam, tp1.Clone())
-						field.Slice = tp1.Slice
-						field.Pointer = tp1.Pointer
-						field.Struct = tp1.Struct.Clone()
-						field.Package = tp1.Package.Clone()
-						//TODO: field 内的struct->field 也要处理
-						break
-					}
-					break
-				}
-
-			}
-
-		}
-
-		for _, param := range method.Result {
-			// 泛型返回值
-			if !param.Generic {
 				continue
 			}
-			//有结构
-			if param.Struct == nil {
-				continue
-			}
-			for _, field := range param.Struct.Field {
-				// 泛型字段
-				if !field.Generic {
-					continue
-				}
-				for _, tp := range param.Struct.TypeParam {
-					if tp.Type != field.Type {
-						continue
-					}
-					field.Parent = true
-					for _, tp1 := range param.TypeParam {
-						if tp1.Index != tp.Index {
-							continue
-						}
-						if field.TypeParam == nil {
-							field.TypeParam = make([]*TypeParam, 0)
-						}
-						field.Struct = tp1.Struct.Clone()
-						field.Package = tp1.Package.Clone()
-
-						handleGenericField(field.Struct, param.TypeParam, param.Struct, p)
-						break
-					}
-					break
-				}
-
+			if old, ok := labels[label.Name]; !ok || enumLabelPriority[label.Source] > enumLabelPriority[old.Source] {
+				labels[label.Name] = label
 			}
 		}
-
 	}
-}
-
-func handleNonGenericField(f *Field, parentStruct *Struct, p *Project) {
-	if f.Package.Type == constants.PackageSamePackage {
-		keyHash1 := internal.GetKeyHash(parentStruct.Package.Path, f.Type)
-		newStruct1 := p.findStruct(keyHash1)
-		if newStruct1 != nil {
-			f.Struct = newStruct1.Clone()
-			f.Package = newStruct1.Package.Clone()
-
+	for _, item := range s.Enum.Enums {
+		if label, ok := labels[item.Name]; ok {
+			item.Label = label.Label
 		}
 	}
 }
 
-func handleGenericField(s *Struct, tps []*TypeParam, parentStruct *Struct, p *Project) {
-	s.VisitFields(func(f *Field) bool {
-		return f.Generic
-	}, func(f *Field) {
-		for idx, typeParam := range s.TypeParam {
-			if typeParam.Type == f.Type {
-				for idx2, t := range tps {
-					if idx == idx2 {
-						//pre := f.Clone()
-						newStruct := t.Struct.Clone()
-
-						f.Struct = newStruct
-						if f.Struct != nil {
-							f.Package = t.Struct.Package.Clone()
-						}
-
-						f.Slice = t.Slice
-						f.Pointer = t.Pointer
-						//TODO: 如果这一级的f.Struct的字段列表中也有泛型类型, 则需要进一步处理. 为兼容多级, 需要递归处理
-
-						handleGenericField2(f, parentStruct, p)
-					}
-				}
+// enumLinker 将类型为枚举的字段/参数/返回值/类型参数关联到对应的枚举定义
+type enumLinker struct {
+	enums   map[string]*Enum
+	visited map[*Struct]bool
+}
 
-			}
+func (l *enumLinker) find(typ string, pkg *Package) *Enum {
+	if pkg == nil {
+		return nil
+	}
+	return l.enums[internal.GetKeyHash(pkg.Path, typ)]
+}
 
+func (l *enumLinker) Struct(s *Struct) {
+	if s == nil || l.visited[s] {
+		return
+	}
+	l.visited[s] = true
+	for _, tp := range s.TypeParam {
+		l.TypeParam(tp)
+	}
+	for _, field := range append(s.Field, s.Declared...) {
+		field.Enum = l.find(field.Type, field.Package)
+		for _, tp := range field.TypeParam {
+			l.TypeParam(tp)
 		}
-	})
-	s.VisitFields(func(f *Field) bool {
-		return !f.Generic
-	}, func(f *Field) {
-		handleNonGenericField(f, parentStruct, p)
-	})
+		l.Struct(field.Struct)
+	}
+	for _, method := range s.Method {
+		l.Function(method)
+	}
 }
-func handleGenericField2(s *Field, parentStruct *Struct, p *Project) {
-	s.Struct.VisitFields(func(f *Field) bool {
-		return f.Generic
-	}, func(f *Field) {
-		if len(s.TypeParam) == len(f.TypeParam) {
-			for idx, typeParam := range s.TypeParam {
-				//pre := f.TypeParam[idx].Clone()
-				newTp := typeParam.Clone()
-				f.TypeParam[idx] = newTp
-				newStruct := newTp.Struct.Clone()
-				if newStruct != nil {
-					f.Package = newStruct.Package.Clone()
-					f.Struct = newStruct
-				}
-				f.Slice = s.Slice
-				f.Pointer = s.Pointer
-				handleGenericField2(f, parentStruct, p)
-			}
+
+func (l *enumLinker) Function(f *Function) {
+	for _, param := range append(f.Param, f.Result...) {
+		param.Enum = l.find(param.Type, param.Package)
+		for _, tp := range param.TypeParam {
+			l.TypeParam(tp)
 		}
+		l.Struct(param.Struct)
+	}
+}
 
-	})
-	s.Struct.VisitFields(func(f *Field) bool {
-		return !f.Generic
-	}, func(f *Field) {
-		handleNonGenericField(f, parentStruct, p)
-	})
+func (l *enumLinker) TypeParam(tp *TypeParam) {
+	tp.Enum = l.find(tp.Type, tp.Package)
+	for _, child := range tp.TypeParam {
+		l.TypeParam(child)
+	}
+	l.Struct(tp.Struct)
 }
 
-// handleParentParam 处理泛型参数/返回值
-// currentStruct: 当前结构
-// param: 参数
-// tps: 类型参数(匿名字段对应的结构)
-func (p *Project) handleParentParam(currentStruct *Struct, param *Param, parentStructTypeParams []*TypeParam) {
-	if !param.Generic {
-		if param.Struct != nil {
-			for _, field := range param.Struct.Field {
-				p.handleNonGenericField(field, param.Struct)
+// handleEnumRef 将类型为枚举的字段/参数/返回值/类型参数关联到对应的枚举定义
+// 便于生成 OpenAPI 的 enum 列表或 TypeScript 的联合类型
+func (p *Project) handleEnumRef() {
+	l := &enumLinker{
+		enums:   make(map[string]*Enum),
+		visited: make(map[*Struct]bool),
+	}
+	for _, file := range p.FileMap {
+		for _, s := range file.Struct {
+			if s.Enum != nil {
+				l.enums[s.KeyHash] = s.Enum
 			}
 		}
-
+	}
+	if len(l.enums) == 0 {
 		return
 	}
-	if param.Struct == nil {
-		// 1. 参数本身的TypeParam eg. param *E / (*E, error) / []*E / ([]*E, error)
-		for _, typeParam := range currentStruct.TypeParam {
-			if param.Type == typeParam.OType {
-				param.Struct = typeParam.Struct.Clone()
-			}
+	for _, file := range p.FileMap {
+		for _, s := range file.Struct {
+			l.Struct(s)
+		}
+		for _, f := range file.Function {
+			l.Function(f)
 		}
-		//for _, tp := range parentStructTypeParams {
-		//	for _, typeParam := range param.TypeParam {
-		//		if typeParam.Key == tp.Key { // 匹配到了
-		//			// 设置索引, 表示参数的这个泛型对应结构中的第几个(后续用于覆盖真实的泛型类型)
-		//			typeParam.Index = tp.Index
-		//
-		//			for _, t := range currentStruct.TypeParam {
-		//				if t.Index == typeParam.Index { // 真实的泛型类型 需要以索引(第几个泛型参数)来匹配
-		//					typeParam.Struct = t.Struct.Clone()
-		//					typeParam.Package = t.Package.Clone()
-		//					param.Struct = t.Struct.Clone()
-		//				}
-		//			}
-		//
-		//		}
-		//	}
-		//}
-		return
 	}
+}
 
-	// 循环处理
-	// 2. 参数结构的TypeParam和其字段 eg. *resp.Resp[*E] / *resp.Resp[[]*E]
-	// 3. 多层嵌套型 eg. *resp.Resp[PageResult[[]*E]]
-
-	// 处理参数结构的各个字段, 如果字段仍然为泛型, 则递归处理
-	p.handleParentMethodParamGeneric(param.Struct, param.TypeParam, currentStruct.TypeParam)
-
-	p.handleParentMethodParamRealGeneric(param.Struct, param.TypeParam, currentStruct.TypeParam)
-	//slog.Info("handleParentMethodParamRealGeneric", "typeName", param.TypeName)
-	tn := strings.Builder{}
-	if param.Slice {
-		tn.WriteString("[]")
+func (p *Project) AfterParseProj() {
+	// 处理枚举合并(将常量合并到对应结构中)
+	p.handleEnum()
+	for _, file := range p.FileMap {
+		for _, s := range file.Struct {
+			p.handleExistsMethods(s)
+		}
 	}
-	if param.Pointer {
-		tn.WriteString("*")
+	// 先计算所有结构展开匿名字段后的结果, 再统一写回
+	// 避免展开时读取到已经被修改过的上级结构(结果不依赖遍历顺序)
+	expanded := make(map[*Struct]*Struct)
+	for _, file := range p.FileMap {
+		for _, s := range file.Struct {
+			expanded[s] = p.handleAnonymousField(s)
+		}
 	}
-	tn.WriteString(param.Struct.TypeName) //*param.Resp
-	var tpString []string
-	tn1 := strings.Builder{}
-	if param.Struct != nil {
-		for _, field := range param.Struct.Field {
-			if !field.Generic {
-				continue
-			}
-			tn1.WriteString(field.TypeName)
-			var tpString1 []string
-			if field.Struct != nil {
-				for _, f := range field.Struct.Field {
-					if !f.Generic {
-						continue
-					}
-					tpString1 = append(tpString1, f.TypeName)
-
-				}
-			}
-			if len(tpString1) > 0 {
-				tn1.WriteString("[" + strings.Join(tpString1, ",") + "]")
+	for s, e := range expanded {
+		s.TypeParam = e.TypeParam
+		s.Field = e.Field
+		s.Declared = e.Declared
+		s.Doc = e.Doc
+		s.Method = e.Method
+	}
+	// 将泛型函数的调用处与函数定义关联
+	p.handleFuncInstance()
+	for _, file := range p.FileMap {
+		for _, f := range file.Function {
+			for _, param := range append(f.Param, f.Result...) {
+				p.resolveTypeParams(param.TypeParam, f.Package.Path)
 			}
-			tpString = append(tpString, tn1.String())
 		}
 	}
-	if len(tpString) > 0 {
-		tn.WriteString("[" + strings.Join(tpString, ",") + "]")
-	}
-	param.TypeName = tn.String()
+	// 在展开和实例化之后关联枚举, 代入到泛型中的枚举类型才能被关联
+	p.handleEnumRef()
 }
 
-func (p *Project) handleParam(currentStruct *Struct, param *Param) {
-	if !param.Generic {
-		if param.Struct != nil {
-			for _, field := range param.Struct.Field {
-				p.handleNonGenericField(field, param.Struct)
+// handleFuncInstance 将各文件中对泛型函数的显式实例化关联到对应的泛型函数上
+// 并代入类型实参得到实例化后的参数和返回值, 同一种实例化只记录一次
+func (p *Project) handleFuncInstance() {
+	funcs := make(map[string]*Function)
+	for _, file := range p.FileMap {
+		for _, f := range file.Function {
+			if f.Generic {
+				funcs[f.Package.Path+"."+f.Name] = f
 			}
 		}
-
-		return
 	}
-	// 循环处理
-	// 1. 参数本身的TypeParam eg. param *E / (*E, error) / []*E / ([]*E, error)
-	// 2. 参数结构的TypeParam和其字段 eg. *resp.Resp[*E] / *resp.Resp[[]*E]
-	// 3. 多层嵌套型 eg. *resp.Resp[PageResult[[]*E]]
-
-	// 递归查找, 更新泛型的Index(代表对应 currentStruct 泛型中的第几个)
-	p.handleMethodParamGeneric(param.Struct, param.TypeParam, currentStruct.TypeParam)
-
-	p.handleMethodParamRealGeneric(param.Struct, param.TypeParam)
-}
-
-func (p *Project) handleParentMethodParamRealGeneric(s *Struct, paramTypeParams []*TypeParam, tps []*TypeParam) {
-	//slog.Info("handleParentMethodParamRealGeneric")
-	if s == nil {
+	if len(funcs) == 0 {
 		return
 	}
-	for idx0, tp0 := range s.TypeParam {
-		for _, field := range s.Field {
-			if !field.Generic {
+	keys := make([]string, 0, len(p.FileMap))
+	for key := range p.FileMap {
+		keys = append(keys, key)
+	}
+	// 按文件排序, 保证输出稳定
+	slices.SortFunc(keys, func(a, b string) int {
+		return strings.Compare(p.FileMap[a].Package.Path+"/"+p.FileMap[a].Name, p.FileMap[b].Package.Path+"/"+p.FileMap[b].Name)
+	})
+	for _, key := range keys {
+		file := p.FileMap[key]
+		for _, inst := range file.Instance {
+			f, ok := funcs[inst.Key()]
+			if !ok {
 				continue
 			}
-			//slog.Info("handleParentMethodParamRealGeneric", "field", field.Name)
-			for _, param := range paramTypeParams {
-				for _, typeParam := range field.TypeParam {
-					if typeParam.Index == param.Index {
-						if param.ElemType == constants.ElemStruct {
-							field.Struct = param.Struct.Clone()
-							field.Pointer = param.Pointer
-							field.Slice = param.Slice
-							if field.Pointer {
-								tmp := field.Struct.TypeName
-								tmp = "*" + tmp
-								if field.Slice {
-									tmp = "[]" + tmp
-								}
-								field.TypeName = tmp
-							} else {
-								tmp := field.Struct.TypeName
-								if field.Slice {
-									tmp = "[]" + tmp
-								}
-								field.TypeName = tmp
-							}
-							p.handleParentMethodParamRealGeneric(field.Struct, field.Struct.TypeParam, tps)
-						} else {
-							for idx2, typeParam1 := range field.TypeParam {
-								if typeParam1.Type != field.Type {
-									continue
-								}
-								for _, tp := range tps {
-									if tp.Index == typeParam1.Index {
-										cloned := tp.Clone()
-										cloned.Pointer = tp0.Pointer
-										cloned.Slice = tp0.Slice
-										field.TypeParam[idx2] = cloned
-										field.Pointer = tp0.Pointer
-										field.Slice = tp0.Slice
-										field.Struct = tp.Struct.Clone()
-										if field.Pointer {
-											tmp := field.Struct.TypeName
-											tmp = "*" + tmp
-											if field.Slice {
-												tmp = "[]" + tmp
-											}
-											field.TypeName = tmp
-										} else {
-											tmp := field.Struct.TypeName
-											if field.Slice {
-												tmp = "[]" + tmp
-											}
-											field.TypeName = tmp
-										}
-										s.TypeParam[idx0] = tp.Clone()
-										s.TypeParam[idx0].Pointer = field.Pointer
-										s.TypeParam[idx0].Slice = field.Slice
-										//TODO: 最外层的param的TypeName需要更新
-										break
-									}
-								}
-							}
-						}
-					}
-				}
+			p.resolveTypeParams(inst.TypeParam, file.Package.Path)
+			in := NewInstantiator(f.TypeParam, inst.TypeParam)
+			inst.Param = CopySlice(f.Param)
+			for _, param := range inst.Param {
+				p.resolveTypeParams(param.TypeParam, f.Package.Path)
+				in.Param(param)
+			}
+			inst.Result = CopySlice(f.Result)
+			for _, result := range inst.Result {
+				p.resolveTypeParams(result.TypeParam, f.Package.Path)
+				in.Param(result)
+			}
+			if !slices.ContainsFunc(f.Instance, func(i *FuncInstance) bool {
+				return joinTypeNames(i.TypeParam) == joinTypeNames(inst.TypeParam)
+			}) {
+				f.Instance = append(f.Instance, inst.Clone())
 			}
-
 		}
 	}
 }
 
-func (p *Project) handleMethodParamRealGeneric(s *Struct, tps []*TypeParam) {
-	//slog.Info("handleMethodParamRealGeneric")
-	if s == nil {
-		return
-	}
-	for _, param := range s.TypeParam {
-		if param.Index == -1 {
-			for _, field := range s.Field {
-				//slog.Info("handleMethodParamRealGeneric", "field", field.Name)
-				has := false
-				for idx2, typeParam := range field.TypeParam {
-					if typeParam.Index != -1 {
-						has = true
-						for _, tp := range tps {
-							if tp.Index == typeParam.Index {
-								field.TypeParam[idx2] = tp.Clone()
-								field.Struct = tp.Struct.Clone()
-								if field.Pointer {
-									tmp := field.Struct.TypeName
-									tmp = "*" + tmp
-									if field.Slice {
-										tmp = "[]" + tmp
-									}
-									field.TypeName = tmp
-								} else {
-									tmp := field.Struct.TypeName
-									if field.Slice {
-										tmp = "[]" + tmp
-									}
-									field.TypeName = tmp
-								}
-								//TODO: 最外层的param的TypeName需要更新
-								break
-							}
-						}
-					}
-				}
-				if !has {
-					p.handleMethodParamRealGeneric(field.Struct, tps)
-				}
-
-			}
+func (p *Project) findStruct(keyHash string) *Struct {
+	for _, f := range p.FileMap {
+		if s := f.FindStruct(keyHash); s != nil {
+			return s
 		}
 	}
+	return nil
 }
 
-// handleGeneric 处理泛型(递归)
-func (p *Project) handleParentMethodParamGeneric(paramStruct *Struct, paramTypeParams []*TypeParam, parentStructTypeParams []*TypeParam) {
-	if paramStruct == nil {
-		return
-	}
-	//slog.Info("handleGeneric", "struct", paramStruct.Name)
-	has := false
-	for _, pstp := range parentStructTypeParams {
-		for idx, ptp := range paramTypeParams {
-			if ptp.Type == pstp.OType {
-				has = true
-				for _, typeParam := range paramStruct.TypeParam {
-					if typeParam.Index == ptp.Index {
-						clonedTypeParam := ptp.Clone()
-						for _, field := range paramStruct.Field {
-							if !field.Generic {
-								continue
-							}
-
-							field.Struct = pstp.Struct.Clone()
-
-							field.Slice = clonedTypeParam.Slice
-							field.Pointer = clonedTypeParam.Pointer
-							if clonedTypeParam.Slice {
-								field.Slice = clonedTypeParam.Slice
-							}
-							if clonedTypeParam.Pointer {
-								field.Pointer = clonedTypeParam.Pointer
-							}
-							field.TypeParam = make([]*TypeParam, 0)
-							field.TypeParam = append(field.TypeParam, clonedTypeParam)
-							field.TypeName = clonedTypeParam.TypeName
-							field.Type = clonedTypeParam.Type
-							if field.Struct != nil {
-								p.handleParentMethodParamGeneric(field.Struct, field.TypeParam, parentStructTypeParams)
-							}
-							clonedTypeParam.Index = pstp.Index // 更新参数的泛型参数的Index
-							clonedTypeParam.Key = pstp.Key
-							paramStruct.TypeParam[idx] = clonedTypeParam
-						}
-					}
-				}
+func (p *Project) FindStruct(keyHash string) *Struct {
+	return p.findStruct(keyHash)
+}
 
+// resolveTypeParams 补全类型实参对应的结构和包路径(逐层)
+// 同包(this)的类型在解析时无法通过 findType 找到, 这里按 pkgPath 在已解析的文件中查找
+func (p *Project) resolveTypeParams(tps []*TypeParam, pkgPath string) {
+	for _, tp := range tps {
+		if tp.Struct == nil && tp.Package != nil && tp.Package.Type == constants.PackageSamePackage {
+			if s := p.findStruct(internal.GetKeyHash(pkgPath, tp.Type)); s != nil {
+				tp.Struct = s.Clone()
+				tp.Package = s.Package.Clone()
+			} else if tp.Package.Path == "" {
+				tp.Package.Path = pkgPath
 			}
 		}
+		p.resolveTypeParams(tp.TypeParam, pkgPath)
 	}
-	if !has {
-		for _, field := range paramStruct.Field {
-			if field.Generic {
-				p.handleParentMethodParamGeneric(field.Struct, paramStruct.TypeParam, parentStructTypeParams)
-			}
-		}
-	}
-
 }
-func (p *Project) handleMethodParamGeneric(s *Struct, tps []*TypeParam, thatTps []*TypeParam) {
-	if s == nil {
-		return
-	}
-	//slog.Info("handleGeneric", "struct", s.Name)
-	for idx, _ := range s.TypeParam {
-		for idx1, t := range tps {
-			if idx == idx1 {
-				clonedTypeParam := t.Clone()
-				for _, field := range s.Field {
-					if field.Generic {
-						field.Struct = t.Struct.Clone()
-						if field.Struct != nil {
-							clonedTypeParam.Index = -1
-							clonedTypeParam.TypeInterface = "nonce"
-						}
-						field.TypeParam = make([]*TypeParam, 0)
-						field.TypeParam = append(field.TypeParam, clonedTypeParam)
-						field.Slice = clonedTypeParam.Slice
-						field.Pointer = clonedTypeParam.Pointer
-						if field.Slice {
-							tmp := field.TypeName
-							if field.Pointer {
-								tmp = "*" + tmp
-							}
-							field.TypeName = "[]" + tmp
-						}
-
-						if field.Struct != nil {
-							p.handleMethodParamGeneric(field.Struct, field.TypeParam, thatTps)
-						}
-						cloneT := t.Clone()
-						cloneT.Index = -1
-						s.TypeParam[idx] = cloneT
-					}
 
-				}
+// handleParam 处理当前结构方法的参数/返回值, 将其中的泛型结构实例化
+func (p *Project) handleParam(currentStruct *Struct, param *Param) {
+	if !param.Generic {
+		if param.Struct != nil {
+			for _, field := range param.Struct.Field {
+				p.handleNonGenericField(field, param.Struct)
 			}
 		}
 
+		return
 	}
-	//slog.Info("handleMethodParamGeneric", "typeParam", s.TypeParam)
-
+	if currentStruct.Generic {
+		// 泛型结构自身的方法只是模板, 在被嵌入时(handleParentParam)再代入实际类型
+		return
+	}
+	p.resolveTypeParams(param.TypeParam, currentStruct.Package.Path)
+	NewInstantiator(nil, nil).Param(param)
 }
 
-func (p *Project) handleParentMethod(currentStruct *Struct, parentMethod *Function, parentStructTypeParams []*TypeParam) {
+// handleParentMethod 处理从上级结构提升过来的方法
+// 方法中的泛型已在上级结构实例化时代入, 这里替换接收器, 并补全非泛型参数结构中的字段
+func (p *Project) handleParentMethod(currentStruct *Struct, parentMethod *Function) {
 	// 处理receiver
 	// 替换拷贝过来的方法的接收器为当前类型
 	parentMethod.Receiver = nil
 	if currentStruct.Method != nil && len(currentStruct.Method) > 0 {
 		parentMethod.Receiver = currentStruct.Method[0].Receiver.Clone()
 	}
-
-	//处理 param
-	for _, param := range parentMethod.Param {
-		p.handleParentParam(currentStruct, param, parentStructTypeParams)
-	}
-	//处理result
-	for _, param := range parentMethod.Result {
-		p.handleParentParam(currentStruct, param, parentStructTypeParams)
+	for _, param := range append(parentMethod.Param, parentMethod.Result...) {
+		if param.Generic || param.Struct == nil {
+			continue
+		}
+		for _, field := range param.Struct.Field {
+			p.handleNonGenericField(field, param.Struct)
+		}
 	}
 }
 
-func (p *Project) handleAnonymousField(currentStruct *Struct) {
-	var delField *Field = nil
-	for _, field := range currentStruct.Field {
-		if field.Struct == nil || !field.Parent {
+// handleAnonymousField 处理匿名字段(嵌入的上级结构)
+// 上级结构可以多层嵌套并带有多个类型实参 eg. UserCtl{ CrudCtl[User, UserDTO, int64] } -> CrudCtl[T, D, ID]{ BaseCtl[T, ID] }
+func (p *Project) handleAnonymousField(currentStruct *Struct) *Struct {
+	return p.expandStruct(currentStruct, make(map[string]bool))
+}
+
+// expandStruct 返回展开了匿名字段后的结构拷贝, 不修改原结构
+// 上级结构先递归展开其自身的匿名字段, 再代入匿名字段上给定的类型实参, 最后将字段和方法提升到当前结构
+func (p *Project) expandStruct(currentStruct *Struct, visiting map[string]bool) *Struct {
+	result := currentStruct.CloneFull()
+	visiting[currentStruct.KeyHash] = true
+	defer delete(visiting, currentStruct.KeyHash)
+
+	expanded := false
+	promoted := make([]*Function, 0)
+	for _, field := range result.Field {
+		// 类型实参可能是当前结构所在包的类型 eg. Resp[Status]
+		p.resolveTypeParams(field.TypeParam, currentStruct.Package.Path)
+		if field.Struct == nil {
+			continue
+		}
+		if !field.Parent {
+			// 普通字段中的泛型结构同样实例化 eg. R Resp[Status]
+			// 泛型结构自身的字段只是模板, 在其被实例化时再代入实际类型
+			if !result.Generic && field.Struct.Generic && len(field.TypeParam) > 0 {
+				field.Struct = p.instantiateField(field, visiting)
+			}
 			continue
 		}
 		//先查找字段对应的结构
 		keyHash := internal.GetKeyHash(field.Struct.Package.Path, field.Struct.Type)
-		fieldStruct := p.findStruct(keyHash).CloneFull()
-
-		// 只要是隐式引用(没有Name),  均将上级结构的字段直接加入到本结构体 (json包就是这么处理的)
-		if field.Name == constants.EmptyName {
-			delField = field
-			currentStruct.TypeParam = CopySlice(field.TypeParam)
-			// 由于字段是隐式引用 (没有Name), 将该结构的字段添加到当前结构的字段列表中
-			for _, f := range fieldStruct.Field {
-				if !f.Private {
-					currentStruct.Field = append(currentStruct.Field, f.Clone())
-				}
-			}
-		} else {
-			// 对于已经经过this处理的结构, 这里更新一下.
-			// 字段对应的结构中可能包含this, 而this的处理在 parse_dir.go:22 进行过
-			// 这里针对的一般是 other 类型
-			field.Struct = fieldStruct
+		declared := p.findStruct(keyHash)
+		if declared == nil || visiting[keyHash] {
+			continue
 		}
-
-		// 上级结构的注释中op=true的注释拷贝过来 其他注释无用
-		// 即 类似 @XXX 这样的注解
-		for _, comment := range fieldStruct.Doc {
-			if !comment.Op {
-				continue
+		fieldStruct := p.expandStruct(declared, visiting)
+		if fieldStruct.Generic && len(field.TypeParam) > 0 {
+			// 上级方法中的嵌套类型可能是上级结构所在包的类型
+			for _, method := range fieldStruct.Method {
+				for _, param := range append(method.Param, method.Result...) {
+					p.resolveTypeParams(param.TypeParam, method.Package.Path)
+				}
 			}
-			currentStruct.Comment = append(currentStruct.Comment, comment.Clone())
-		}
-
-		// 提取上级结构的泛型类型定义(这里一般还是T,E这样的'Type')
-		// 用于后面receiver param result的处理
-		tps := CopySlice(fieldStruct.TypeParam)
-		for _, tp := range tps {
-			for _, param := range currentStruct.TypeParam {
-				if tp.Index == param.Index {
-					param.OType = tp.Type
+			fieldStruct = NewInstantiator(nil, nil).Struct(fieldStruct, field.TypeParam)
+			// 非泛型结构记录其嵌入的每个泛型上级结构的实际类型 (OType 为上级结构中的泛型参数名, Embed 为嵌入字段)
+			if !result.Generic {
+				for _, tp := range fieldStruct.TypeParam {
+					arg := tp.Clone()
+					arg.Embed = field.SelectorName()
+					result.TypeParam = append(result.TypeParam, arg)
 				}
 			}
 		}
+		// 嵌入字段指向展开后的上级结构, 其字段在最后按层级统一提升
+		field.Struct = fieldStruct
+		expanded = true
+
+		// 上级结构的注解(@XXX)按继承规则合并到当前结构, 其他注释无用
+		inheritAttrs(result, fieldStruct)
 
 		for _, function := range fieldStruct.Method {
 			// 跳过私有方法和非操作方法
@@ -731,22 +523,69 @@ func (p *Project) handleAnonymousField(currentStruct *Struct) {
 				continue
 			}
 			cloned := function.Clone()
-			//slog.Info("handleParentMethod", "method", cloned.Name)
-			p.handleParentMethod(currentStruct, cloned, tps)
-			currentStruct.Method = append(currentStruct.Method, cloned)
+			cloned.Depth = function.Depth + 1
+			cloned.Embed = field.SelectorName()
+			if function.Embed != "" {
+				cloned.Embed += "." + function.Embed
+			}
+			if cloned.Origin == nil {
+				cloned.Origin = function.Receiver.Clone()
+			}
+			p.handleParentMethod(result, cloned)
+			promoted = append(promoted, cloned)
 		}
+	}
+	if len(promoted) > 0 {
+		// 本结构的方法遮蔽上级结构的同名方法, 避免出现重复的方法(路由)
+		result.Method = promoteMethods(result.Method, promoted)
 		// 简单排序一下
-		slices.SortFunc(currentStruct.Method, func(a, b *Function) int {
+		slices.SortStableFunc(result.Method, func(a, b *Function) int {
 			return a.Index - b.Index
 		})
+	}
+	if expanded {
+		// 按 Go 的选择器规则提升字段: 层级浅的字段遮蔽深的, 同层级同名的字段都不提升
+		// 展开的嵌入字段本身不再出现在 Field 中, 声明时的字段保留在 Declared 中
+		result.Declared = result.Field
+		result.Field = promoteFields(result.Declared)
+	}
+	return result
+}
 
+// instantiateField 使用字段上的类型实参实例化字段对应的泛型结构(先展开其匿名字段)
+func (p *Project) instantiateField(field *Field, visiting map[string]bool) *Struct {
+	fieldStruct := field.Struct
+	keyHash := internal.GetKeyHash(fieldStruct.Package.Path, fieldStruct.Type)
+	if declared := p.findStruct(keyHash); declared != nil && !visiting[keyHash] {
+		fieldStruct = p.expandStruct(declared, visiting)
 	}
-	// 对于隐式引用(没有Name)的字段, 删除它, 对后面没什么用了
-	if delField != nil {
-		// 也可以不删除, 而是将字段相关设置为非公开字段等
-		currentStruct.Field = slices.DeleteFunc(currentStruct.Field, func(i *Field) bool {
-			return i.Name == delField.Name && i.Type == delField.Type && i.TypeName == delField.TypeName
-		})
+	return NewInstantiator(nil, nil).Struct(fieldStruct, field.TypeParam)
+}
+
+// inheritAttrs 按注解的继承规则(AnnotationDef.Inherit)将上级结构的注解追加到当前结构的 Doc 中
+// 继承来的注解记录其来源 Origin; 多个上级结构有同一 override 注解时, 先嵌入的为准
+func inheritAttrs(result *Struct, parent *Struct) {
+	own := append(slices.Clone(result.Doc), result.Comment...)
+	for _, comment := range append(slices.Clone(parent.Doc), parent.Comment...) {
+		if !comment.Op {
+			continue
+		}
+		switch Annotations.Lookup(comment.AttrName()).InheritMode() {
+		case constants.InheritNever:
+			continue
+		case constants.InheritOverride:
+			if slices.ContainsFunc(own, func(c *Comment) bool {
+				return c.Op && c.AttrType == comment.AttrType && c.CustomAttr == comment.CustomAttr
+			}) {
+				continue
+			}
+		}
+		cloned := comment.Clone()
+		if cloned.Origin == "" {
+			cloned.Origin = parent.Package.Name + "." + parent.Name
+		}
+		result.Doc = append(result.Doc, cloned)
+		own = append(own, cloned)
 	}
 }
 
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"github.com/linxlib/astp/internal"
+	"testing"
+)
+
+func enumConst(name string, value int, pkg *Package) *Const {
+	return &Const{Name: name, Type: "Status", TypeName: "order.Status", TypePkgPath: "demo/order", Value: value, ElemType: constants.ElemEnum, Package: pkg}
+}
+
+func Test_handleEnum(t *testing.T) {
+	order := &Package{Name: "order", Path: "demo/order"}
+	pay := &Package{Name: "pay", Path: "demo/pay"}
+	status := &Struct{Name: "Status", Type: "Status", TypeName: "order.Status", KeyHash: internal.GetKeyHash(order.Path, "Status"), Package: order}
+	p := &Project{}
+	// 类型与常量分别在不同的文件中, 另有一个常量在其他包中
+	p.AddFile(&File{KeyHash: "pay.go", Name: "pay.go", Package: pay, Const: []*Const{enumConst("Refunded", 10, pay)}})
+	p.AddFile(&File{KeyHash: "status.go", Name: "status.go", Package: order, Const: []*Const{enumConst("Created", 1, order), enumConst("Paid", 2, order)}})
+	p.AddFile(&File{KeyHash: "model.go", Name: "model.go", Package: order, Struct: []*Struct{status}})
+	p.handleEnum()
+	if status.Enum == nil || len(status.Enum.Enums) != 3 {
+		t.Fatalf("enum: %v", status.Enum)
+	}
+	items := status.Enum.Enums
+	if items[0].Name != "Created" || items[1].Name != "Paid" || items[2].Name != "Refunded" || items[2].Index != 2 {
+		t.Fatalf("items: %v", items)
+	}
+	if items[0].Package != nil || items[2].Package.Path != pay.Path {
+		t.Fatalf("packages: %v, %v", items[0].Package, items[2].Package)
+	}
+}
+
+func Test_handleEnumRef(t *testing.T) {
+	order := &Package{Name: "order", Path: "demo/order"}
+	status := &Struct{Name: "Status", Type: "Status", KeyHash: internal.GetKeyHash(order.Path, "Status"), Package: order,
+		Enum: &Enum{Name: "Status", Enums: []*EnumItem{{Name: "Paid", Value: 1}}}}
+	field := &Field{Name: "State", Type: "Status", Package: order}
+	nested := &Field{Name: "State", Type: "Status", Package: order}
+	param := &Param{Name: "orders", Type: "List", Package: order,
+		TypeParam: []*TypeParam{{Type: "Status", Package: order}},
+		Struct:    &Struct{Name: "List", Field: []*Field{nested}}}
+	s := &Struct{Name: "Order", Field: []*Field{field}, Method: []*Function{{Name: "Find", Param: []*Param{param}}}}
+	p := &Project{}
+	p.AddFile(&File{KeyHash: "order.go", Package: order, Struct: []*Struct{status, s}})
+	p.handleEnumRef()
+	if field.Enum != status.Enum || nested.Enum != status.Enum || param.TypeParam[0].Enum != status.Enum {
+		t.Fatalf("field: %v, nested: %v, type param: %v", field.Enum, nested.Enum, param.TypeParam[0].Enum)
+	}
+	if param.Enum != nil {
+		t.Fatalf("param enum: %v", param.Enum)
+	}
+}
+
+func Test_inheritAttrs(t *testing.T) {
+	pkg := &Package{Name: "base", Path: "demo/base"}
+	parent := &Struct{Name: "BaseCtl", Package: pkg, Doc: []*Comment{
+		OfComment(0, "@Base /api", "BaseCtl"),
+		OfComment(1, "@Deprecated", "BaseCtl"),
+		OfComment(2, "@Route /base", "BaseCtl"),
+		OfComment(3, "BaseCtl 基础控制器", "BaseCtl"),
+	}}
+	child := &Struct{Name: "UserCtl", Package: pkg, Doc: []*Comment{
+		OfComment(0, "@Controller", "UserCtl"),
+		OfComment(1, "@Route /user", "UserCtl"),
+	}}
+	inheritAttrs(child, parent)
+	// @Base 被继承, @Deprecated 不继承, @Route 以下级的为准
+	if len(child.Doc) != 3 || child.Doc[2].AttrType != constants.AT_BASE || child.Doc[2].Origin != "base.BaseCtl" {
+		t.Fatal(child.Doc)
+	}
+	if child.GetAttrValue(constants.AT_ROUTE) != "/user" || child.HasAttr(constants.AT_DEPRECATED) || parent.Doc[0].Origin != "" {
+		t.Fatal(child.Doc)
+	}
+
+	// 再嵌入一层时保留最初的来源
+	grandchild := &Struct{Name: "AdminCtl", Package: &Package{Name: "admin"}}
+	inheritAttrs(grandchild, child)
+	if len(grandchild.Doc) != 3 || grandchild.Doc[2].Origin != "base.BaseCtl" || grandchild.Doc[0].Origin != "base.UserCtl" {
+		t.Fatal(grandchild.Doc)
+	}
+}
@@ -19,7 +19,7 @@ type Receiver struct {
 }
 
 func (r *Receiver) String() string {
-	return fmt.Sprintf("%s(%s)", r.Name, r.Pointer)
+	return fmt.Sprintf("%s(%t)", r.Name, r.Pointer)
 }
 
 func (r *Receiver) Clone() *Receiver {
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+	"github.com/linxlib/astp/constants"
+	"strings"
+)
+
+var _ IElem[*Rule] = (*Rule)(nil)
+
+// Rule 校验标签(validate/binding)中的一条规则, 按 go-playground/validator 的语法解析
+// eg. binding:"required,min=1,max=64,email" / validate:"oneof=a b"
+type Rule struct {
+	Name      string             `json:"name"`                // 规则名称 eg. min / oneof / email
+	Kind      constants.RuleKind `json:"kind"`                // 规则的类别, 方便生成 schema eg. gte 为 min
+	Param     string             `json:"param,omitempty"`     // 原始参数 eg. 1 / a b
+	Values    []string           `json:"values,omitempty"`    // oneof 的候选值
+	Exclusive bool               `json:"exclusive,omitempty"` // gt/lt 不包含边界值
+	Elem      bool               `json:"elem,omitempty"`      // dive 之后的规则, 作用于切片/map的元素
+	Or        []*Rule            `json:"or,omitempty"`        // 满足其一即可的规则 eg. rgb|rgba
+}
+
+func (r *Rule) String() string {
+	if r.Param == "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s=%s", r.Name, r.Param)
+}
+
+func (r *Rule) Clone() *Rule {
+	if r == nil {
+		return nil
+	}
+	return &Rule{
+		Name:      r.Name,
+		Kind:      r.Kind,
+		Param:     r.Param,
+		Values:    append([]string(nil), r.Values...),
+		Exclusive: r.Exclusive,
+		Elem:      r.Elem,
+		Or:        CopySlice(r.Or),
+	}
+}
+
+// ruleFormats 校验字符串格式的规则
+var ruleFormats = map[string]bool{
+	"email": true, "url": true, "uri": true, "http_url": true, "uuid": true, "uuid3": true, "uuid4": true, "uuid5": true,
+	"ip": true, "ipv4": true, "ipv6": true, "cidr": true, "mac": true, "hostname": true, "fqdn": true,
+	"alpha": true, "alphanum": true, "numeric": true, "number": true, "hexadecimal": true, "hexcolor": true,
+	"base64": true, "json": true, "jwt": true, "e164": true, "datetime": true, "ascii": true, "lowercase": true, "uppercase": true,
+}
+
+// ParseRules 解析校验标签中的规则
+// 规则以,分隔, | 表示或, 参数中的 0x2C 和 0x7C 分别表示 , 和 |
+func ParseRules(tag *Tag) []*Rule {
+	if tag == nil || tag.Value == "" {
+		return nil
+	}
+	rules := make([]*Rule, 0)
+	elem := false
+	for _, s := range strings.Split(tag.Value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		var r *Rule
+		if alternatives := strings.Split(s, "|"); len(alternatives) > 1 {
+			r = &Rule{Name: s, Kind: constants.RuleOr}
+			for _, alt := range alternatives {
+				r.Or = append(r.Or, newRule(alt))
+			}
+		} else {
+			r = newRule(s)
+		}
+		r.Elem = elem
+		if r.Kind == constants.RuleDive {
+			elem = true
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+func newRule(s string) *Rule {
+	name, param, _ := strings.Cut(s, "=")
+	param = strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+	r := &Rule{Name: name, Kind: constants.RuleOther, Param: param}
+	switch name {
+	case "required":
+		r.Kind = constants.RuleRequired
+	case "omitempty":
+		r.Kind = constants.RuleOmitEmpty
+	case "min", "gte", "gt":
+		r.Kind = constants.RuleMin
+		r.Exclusive = name == "gt"
+	case "max", "lte", "lt":
+		r.Kind = constants.RuleMax
+		r.Exclusive = name == "lt"
+	case "len":
+		r.Kind = constants.RuleLen
+	case "regexp", "pattern":
+		r.Kind = constants.RulePattern
+	case "oneof":
+		r.Kind = constants.RuleEnum
+		r.Values = ruleValues(param)
+	case "dive":
+		r.Kind = constants.RuleDive
+	default:
+		if ruleFormats[name] {
+			r.Kind = constants.RuleFormat
+		}
+	}
+	return r
+}
+
+// ruleValues oneof 的候选值, 以空格分隔, 可以用单引号包含空格 eg. 'red green' blue
+func ruleValues(param string) []string {
+	values := make([]string, 0)
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if param[0] == '\'' {
+			if end := strings.IndexByte(param[1:], '\''); end >= 0 {
+				values = append(values, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		value, rest, _ := strings.Cut(param, " ")
+		values = append(values, value)
+		param = rest
+	}
+	return values
+}
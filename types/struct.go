@@ -9,23 +9,28 @@ import (
 var _ IElem[*Struct] = (*Struct)(nil)
 
 type Struct struct {
-	Name      string             `json:"name"`
-	Index     int                `json:"index"`
-	Key       string             `json:"-"`
-	KeyHash   string             `json:"-"`
-	TypeName  string             `json:"type_name"`
-	Type      string             `json:"type"`
-	Private   bool               `json:"private,omitempty"`
-	Generic   bool               `json:"generic,omitempty"`
-	Top       bool               `json:"top,omitempty"`
-	ElemType  constants.ElemType `json:"elem_type"`
-	TypeParam []*TypeParam       `json:"type_param,omitempty"`
-	Field     []*Field           `json:"field,omitempty"`
-	Doc       []*Comment         `json:"doc,omitempty"`
-	Comment   []*Comment         `json:"comment,omitempty"`
-	Method    []*Function        `json:"method,omitempty"`
-	Package   *Package           `json:"package,omitempty"`
-	Enum      *Enum              `json:"enum,omitempty"`
+	Name       string             `json:"name"`
+	Index      int                `json:"index"`
+	Key        string             `json:"-"`
+	KeyHash    string             `json:"-"`
+	TypeName   string             `json:"type_name"`
+	Type       string             `json:"type"`
+	Private    bool               `json:"private,omitempty"`
+	Generic    bool               `json:"generic,omitempty"`
+	Top        bool               `json:"top,omitempty"`
+	ElemType   constants.ElemType `json:"elem_type"`
+	TypeParam  []*TypeParam       `json:"type_param,omitempty"`
+	Field      []*Field           `json:"field,omitempty"`
+	Doc        []*Comment         `json:"doc,omitempty"`
+	Comment    []*Comment         `json:"comment,omitempty"`
+	Method     []*Function        `json:"method,omitempty"`
+	Package    *Package           `json:"package,omitempty"`
+	Enum       *Enum              `json:"enum,omitempty"`
+	Constraint *Constraint        `json:"constraint,omitempty"` // 仅用于约束接口 eg. type Number interface{ ~int | ~float64 }
+	Alias      bool               `json:"alias,omitempty"`      // type A = B
+	Underlying *TypeParam         `json:"underlying,omitempty"` // 右侧的类型 eg. type UserPage = Page[User] 中的 Page[User]
+	Declared   []*Field           `json:"declared,omitempty"`   // 声明时的字段(含嵌入字段), 仅在有嵌入结构时记录, Field 为展开后的字段
+	Pos        string             `json:"pos,omitempty"`        // 声明位置 eg. models/user.go:12
 
 	rValue reflect.Value
 	value  any
@@ -73,48 +78,79 @@ func (s *Struct) Clone() *Struct {
 		return nil
 	}
 	return &Struct{
-		Index:     s.Index,
-		Name:      s.Name,
-		Key:       s.Key,
-		KeyHash:   s.KeyHash,
-		TypeName:  s.TypeName,
-		Type:      s.Type,
-		Private:   s.Private,
-		Generic:   s.Generic,
-		TypeParam: CopySlice(s.TypeParam),
-		Field:     CopySlice(s.Field),
-		Doc:       CopySlice(s.Doc),
-		ElemType:  s.ElemType,
-		Enum:      s.Enum.Clone(),
-		Top:       s.Top,
-		Comment:   CopySlice(s.Comment),
+		Index:      s.Index,
+		Name:       s.Name,
+		Key:        s.Key,
+		KeyHash:    s.KeyHash,
+		TypeName:   s.TypeName,
+		Type:       s.Type,
+		Private:    s.Private,
+		Generic:    s.Generic,
+		TypeParam:  CopySlice(s.TypeParam),
+		Field:      CopySlice(s.Field),
+		Declared:   CopySlice(s.Declared),
+		Doc:        CopySlice(s.Doc),
+		ElemType:   s.ElemType,
+		Enum:       s.Enum.Clone(),
+		Constraint: s.Constraint.Clone(),
+		Top:        s.Top,
+		Alias:      s.Alias,
+		Underlying: s.Underlying.Clone(),
+		Comment:    CopySlice(s.Comment),
+		Pos:        s.Pos,
 		//Method:    CopySlice(s.Method),
 		Package: s.Package.Clone(),
 	}
 }
 
+// Ref returns a reference to the struct which only keeps its identity (no fields and methods)
+// used by recursive fields to avoid copying the struct again
+func (s *Struct) Ref() *Struct {
+	if s == nil {
+		return nil
+	}
+	return &Struct{
+		Index:    s.Index,
+		Name:     s.Name,
+		Key:      s.Key,
+		KeyHash:  s.KeyHash,
+		TypeName: s.TypeName,
+		Type:     s.Type,
+		Private:  s.Private,
+		Generic:  s.Generic,
+		ElemType: s.ElemType,
+		Top:      s.Top,
+		Package:  s.Package.Clone(),
+	}
+}
+
 // CloneFull returns a deep copy of the struct with the methods
 func (s *Struct) CloneFull() *Struct {
 	if s == nil {
 		return nil
 	}
 	return &Struct{
-		Index:     s.Index,
-		Name:      s.Name,
-		Key:       s.Key,
-		KeyHash:   s.KeyHash,
-		TypeName:  s.TypeName,
-		Type:      s.Type,
-		Private:   s.Private,
-		Generic:   s.Generic,
-		TypeParam: CopySlice(s.TypeParam),
-		Field:     CopySlice(s.Field),
-		Doc:       CopySlice(s.Doc),
-		Enum:      s.Enum.Clone(),
-		Top:       s.Top,
-		Comment:   CopySlice(s.Comment),
-		Method:    CopySlice(s.Method),
-		Package:   s.Package.Clone(),
+		Index:      s.Index,
+		Name:       s.Name,
+		Key:        s.Key,
+		KeyHash:    s.KeyHash,
+		TypeName:   s.TypeName,
+		Type:       s.Type,
+		Private:    s.Private,
+		Generic:    s.Generic,
+		TypeParam:  CopySlice(s.TypeParam),
+		Field:      CopySlice(s.Field),
+		Declared:   CopySlice(s.Declared),
+		Doc:        CopySlice(s.Doc),
+		Enum:       s.Enum.Clone(),
+		Constraint: s.Constraint.Clone(),
+		Top:        s.Top,
+		Alias:      s.Alias,
+		Underlying: s.Underlying.Clone(),
+		Comment:    CopySlice(s.Comment),
+		Pos:        s.Pos,
+		Method:     CopySlice(s.Method),
+		Package:    s.Package.Clone(),
 	}
 }
 
@@ -0,0 +1,161 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var _ IElem[*Tag] = (*Tag)(nil)
+
+// Tag 结构体标签中的一项 eg. json:"name,omitempty"
+type Tag struct {
+	Key     string   `json:"key"`               // eg. json
+	Value   string   `json:"value"`             // 原始的值 eg. name,omitempty
+	Name    string   `json:"name,omitempty"`    // 名称 eg. name / - , gorm 为 column 的值, validate/binding 没有名称
+	Options []string `json:"options,omitempty"` // 选项 eg. omitempty / string / inline, gorm 以;分隔, validate/binding 为所有规则
+}
+
+func (t *Tag) String() string {
+	return t.Key + ":" + strconv.Quote(t.Value)
+}
+
+func (t *Tag) Clone() *Tag {
+	if t == nil {
+		return nil
+	}
+	return &Tag{
+		Key:     t.Key,
+		Value:   t.Value,
+		Name:    t.Name,
+		Options: append([]string(nil), t.Options...),
+	}
+}
+
+// HasOption 是否有某个选项 eg. omitempty, 对于 key:value / key=value 形式的选项比较其key (gorm的选项不区分大小写)
+func (t *Tag) HasOption(name string) bool {
+	_, ok := t.lookup(name)
+	return ok
+}
+
+// Option 返回 key:value / key=value 形式的选项的值 eg. gorm:"size:64" 中 size 的值为 64
+func (t *Tag) Option(name string) string {
+	v, _ := t.lookup(name)
+	return v
+}
+
+func (t *Tag) lookup(name string) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	for _, option := range t.Options {
+		key, value, _ := strings.Cut(option, t.optionSep())
+		if key == name || (t.Key == "gorm" && strings.EqualFold(key, name)) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (t *Tag) optionSep() string {
+	if t.Key == "gorm" {
+		return ":"
+	}
+	return "="
+}
+
+// Ignored 是否忽略该字段 eg. json:"-" (json:"-," 表示名称为 -)
+func (t *Tag) Ignored() bool {
+	return t != nil && t.Name == "-" && len(t.Options) == 0
+}
+
+// OmitEmpty 是否有 omitempty 选项
+func (t *Tag) OmitEmpty() bool {
+	return t.HasOption("omitempty")
+}
+
+// newTag 按不同的key解析标签的值
+func newTag(key string, value string) *Tag {
+	t := &Tag{Key: key, Value: value}
+	switch key {
+	case "gorm":
+		for _, option := range strings.Split(value, ";") {
+			if option = strings.TrimSpace(option); option != "" {
+				t.Options = append(t.Options, option)
+			}
+		}
+		t.Name = t.Option("column")
+	case "validate", "binding":
+		if value != "" {
+			t.Options = strings.Split(value, ",")
+		}
+	default:
+		parts := strings.Split(value, ",")
+		t.Name = parts[0]
+		t.Options = parts[1:]
+	}
+	return t
+}
+
+// ParseTag 解析结构体标签(可以带反引号或双引号)
+// 按 reflect.StructTag 的约定校验语法, 出错时返回已解析的部分和错误
+func ParseTag(tag string) ([]*Tag, error) {
+	if unquoted, err := strconv.Unquote(tag); err == nil {
+		tag = unquoted
+	}
+	tags := make([]*Tag, 0)
+	keys := make(map[string]bool)
+	for tag != "" {
+		// 跳过前导空格
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 {
+			return tags, errors.New("bad syntax for struct tag key")
+		}
+		if i+1 >= len(tag) || tag[i] != ':' {
+			return tags, errors.New("bad syntax for struct tag pair")
+		}
+		if tag[i+1] != '"' {
+			return tags, errors.New("bad syntax for struct tag value")
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// 查找值的结束引号
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return tags, errors.New("bad syntax for struct tag value")
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return tags, errors.New("bad syntax for struct tag value")
+		}
+		tag = tag[i+1:]
+		if keys[key] {
+			return tags, fmt.Errorf("duplicate struct tag key %q", key)
+		}
+		keys[key] = true
+		tags = append(tags, newTag(key, value))
+		if tag != "" && tag[0] != ' ' {
+			return tags, errors.New("struct tag pairs not separated by spaces")
+		}
+	}
+	return tags, nil
+}
@@ -14,8 +14,12 @@ type TypeParam struct {
 	Pointer       bool               `json:"pointer,omitempty"`
 	Slice         bool               `json:"slice,omitempty"`
 	TypeInterface string             `json:"type_interface,omitempty"`
+	Constraint    *Constraint        `json:"constraint,omitempty"`
+	TypeParam     []*TypeParam       `json:"type_param,omitempty"` // 类型实参自身的类型实参 eg. PageResult[[]*User]
 	Struct        *Struct            `json:"struct,omitempty"`
 	Package       *Package           `json:"package,omitempty"`
+	Enum          *Enum              `json:"enum,omitempty"`  // 类型为枚举时, 对应的枚举定义
+	Embed         string             `json:"embed,omitempty"` // 非泛型结构中记录的上级结构的类型实参, 为对应的嵌入字段 eg. BaseCtl
 }
 
 func (t *TypeParam) String() string {
@@ -29,14 +33,19 @@ func (t *TypeParam) Clone() *TypeParam {
 	return &TypeParam{
 		Index:         t.Index,
 		Type:          t.Type,
+		OType:         t.OType,
+		Embed:         t.Embed,
 		TypeName:      t.TypeName,
 		ElemType:      t.ElemType,
 		Pointer:       t.Pointer,
 		Key:           t.Key,
 		Slice:         t.Slice,
 		TypeInterface: t.TypeInterface,
+		Constraint:    t.Constraint.Clone(),
+		TypeParam:     CopySlice(t.TypeParam),
 		Struct:        t.Struct.Clone(),
 		Package:       t.Package.Clone(),
+		Enum:          t.Enum.Clone(),
 	}
 }
 func (t *TypeParam) CloneTiny() *TypeParam {
@@ -46,13 +55,18 @@ func (t *TypeParam) CloneTiny() *TypeParam {
 	return &TypeParam{
 		Index:         t.Index,
 		Type:          t.Type,
+		OType:         t.OType,
+		Embed:         t.Embed,
 		TypeName:      t.TypeName,
 		ElemType:      t.ElemType,
 		Key:           t.Key,
 		Pointer:       t.Pointer,
 		Slice:         t.Slice,
 		TypeInterface: t.TypeInterface,
+		Constraint:    t.Constraint.Clone(),
+		TypeParam:     CopySlice(t.TypeParam),
 		//Struct:        t.Struct.Clone(),
 		Package: t.Package.Clone(),
+		Enum:    t.Enum.Clone(),
 	}
 }
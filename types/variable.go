@@ -8,17 +8,21 @@ import (
 var _ IElem[*Variable] = (*Variable)(nil)
 
 type Variable struct {
-	Name     string             `json:"name"`
-	ElemType constants.ElemType `json:"elem_type"`
-	Index    int                `json:"index"`
-	Value    any                `json:"value"`
-	Type     string             `json:"type"`
-	TypeName string             `json:"type_name"`
-	Iota     bool               `json:"iota,omitempty"`
-	Package  *Package           `json:"package,omitempty"`
-	Struct   *Struct            `json:"struct,omitempty"`
-	Doc      []*Comment         `json:"doc,omitempty"`
-	Comment  []*Comment         `json:"comment,omitempty"`
+	Name        string             `json:"name"`
+	ElemType    constants.ElemType `json:"elem_type"`
+	Index       int                `json:"index"`
+	Value       any                `json:"value"`
+	Type        string             `json:"type"`
+	TypeName    string             `json:"type_name"`
+	Iota        bool               `json:"iota,omitempty"`
+	Expr        string             `json:"expr,omitempty"`          // 值的表达式 eg. 1 << iota
+	Exact       string             `json:"exact,omitempty"`         // 常量的精确值 eg. 1/3 / "abc"
+	TypePkgPath string             `json:"type_pkg_path,omitempty"` // 类型所在的包路径, 类型可以声明在其他包中 eg. const Paid order.Status = 1, 内置类型为空
+	Init        *Expr              `json:"init,omitempty"`          // 变量的初始化表达式 eg. Config{Port: 8080} / errors.New("not found")
+	Package     *Package           `json:"package,omitempty"`       // 声明所在的包
+	Struct      *Struct            `json:"struct,omitempty"`
+	Doc         []*Comment         `json:"doc,omitempty"`
+	Comment     []*Comment         `json:"comment,omitempty"`
 }
 
 func (v *Variable) String() string {
@@ -30,15 +34,21 @@ func (v *Variable) Clone() *Variable {
 		return nil
 	}
 	return &Variable{
-		Name:     v.Name,
-		ElemType: v.ElemType,
-		Type:     v.Type,
-		Value:    v.Value,
-		TypeName: v.TypeName,
-		Package:  v.Package.Clone(),
-		Struct:   v.Struct.Clone(),
-		Doc:      CopySlice(v.Doc),
-		Comment:  CopySlice(v.Comment),
+		Name:        v.Name,
+		ElemType:    v.ElemType,
+		Type:        v.Type,
+		Value:       v.Value,
+		Index:       v.Index,
+		Iota:        v.Iota,
+		Expr:        v.Expr,
+		Exact:       v.Exact,
+		TypePkgPath: v.TypePkgPath,
+		Init:        v.Init.Clone(),
+		TypeName:    v.TypeName,
+		Package:     v.Package.Clone(),
+		Struct:      v.Struct.Clone(),
+		Doc:         CopySlice(v.Doc),
+		Comment:     CopySlice(v.Comment),
 	}
 }
 
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"github.com/linxlib/astp/constants"
+)
+
+var _ IElem[*WireProperty] = (*WireProperty)(nil)
+
+// WireProperty 结构在某种编码下序列化后的一个属性
+type WireProperty struct {
+	Name      string `json:"name"`                 // 编码后的键 eg. user_name
+	Field     *Field `json:"field"`                // 对应的字段, 提升上来的字段带有 Depth 和 Embed
+	Tag       *Tag   `json:"tag,omitempty"`        // 对应编码的标签
+	OmitEmpty bool   `json:"omit_empty,omitempty"` // 零值时不输出 eg. json:",omitempty"
+	Optional  bool   `json:"optional,omitempty"`   // 可能不出现或为空: omitempty 或 指针
+	AsString  bool   `json:"as_string,omitempty"`  // 数值/布尔编码为字符串 eg. json:",string"
+	Attr      bool   `json:"attr,omitempty"`       // xml 属性 eg. xml:"id,attr"
+}
+
+func (w *WireProperty) String() string {
+	return fmt.Sprintf("%s(%s)", w.Name, w.Field.TypeName)
+}
+
+func (w *WireProperty) Clone() *WireProperty {
+	if w == nil {
+		return nil
+	}
+	return &WireProperty{
+		Name:      w.Name,
+		Field:     w.Field.Clone(),
+		Tag:       w.Tag.Clone(),
+		OmitEmpty: w.OmitEmpty,
+		Optional:  w.Optional,
+		AsString:  w.AsString,
+		Attr:      w.Attr,
+	}
+}
+
+// scalarTypes 可以使用 json:",string" 的内置类型
+var scalarTypes = map[string]bool{
+	"string": true, "bool": true, "float32": true, "float64": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "byte": true,
+}
+
+// WireShape 结构在某种编码下的有效属性列表(已展开嵌入结构)
+// 处理了嵌入字段的提升, 标签重命名, "-" 忽略的字段, 非公开字段, omitempty, ,string 以及指针的可选性
+// eg. WireShape(constants.CodecJSON)
+func (s *Struct) WireShape(codec constants.Codec) []*WireProperty {
+	fields := s.wireFields(codec)
+	result := make([]*WireProperty, 0, len(fields))
+	for _, f := range fields {
+		tag := f.TagOf(codec)
+		p := &WireProperty{
+			Name:      f.WireName(codec),
+			Field:     f,
+			Tag:       tag.Clone(),
+			OmitEmpty: tag.OmitEmpty(),
+			Attr:      codec == constants.CodecXML && tag.HasOption("attr"),
+		}
+		// encoding/json 只对字符串/数值/布尔类型(及其指针)应用 ,string
+		p.AsString = codec == constants.CodecJSON && tag.HasOption("string") && !f.Slice && (f.Enum != nil || scalarTypes[f.Type])
+		p.Optional = p.OmitEmpty || f.Pointer
+		result = append(result, p)
+	}
+	return result
+}
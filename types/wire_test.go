@@ -0,0 +1,59 @@
+package types
+
+import (
+	"github.com/linxlib/astp/constants"
+	"strings"
+	"testing"
+)
+
+func Test_WireShape(t *testing.T) {
+	// type Audit struct { CreatedBy string `json:"created_by" yaml:"created_by"` }
+	// type User struct {
+	//     XMLName xml.Name `xml:"user"`
+	//     Audit            `yaml:",inline"`
+	//     ID     int64    `json:"id,string" xml:"id,attr"`
+	//     Name   *string  `json:"name,omitempty" form:"user_name"`
+	//     Secret string   `json:"-" xml:"-" yaml:"-" form:"-"`
+	//     age    int
+	// }
+	audit := &Struct{Name: "Audit", Type: "Audit", TypeName: "Audit", Field: []*Field{
+		{Name: "CreatedBy", Type: "string", Tag: "`json:\"created_by\" yaml:\"created_by\"`"},
+	}}
+	s := &Struct{Name: "User", Field: []*Field{
+		{Name: "XMLName", Type: "Name", Tag: "`xml:\"user\"`"},
+		embedField(audit, false, "`yaml:\",inline\"`"),
+		{Name: "ID", Type: "int64", Tag: "`json:\"id,string\" xml:\"id,attr\"`"},
+		{Name: "Name", Type: "string", Pointer: true, Tag: "`json:\"name,omitempty\" form:\"user_name\"`"},
+		{Name: "Secret", Type: "string", Tag: "`json:\"-\" xml:\"-\" yaml:\"-\" form:\"-\"`"},
+		{Name: "age", Type: "int", Private: true},
+	}}
+	shape := func(codec constants.Codec) string {
+		names := make([]string, 0)
+		for _, p := range s.WireShape(codec) {
+			names = append(names, p.Name)
+		}
+		return strings.Join(names, ",")
+	}
+	want := map[constants.Codec]string{
+		constants.CodecJSON: "XMLName,created_by,id,name",
+		constants.CodecXML:  "CreatedBy,id,Name",
+		constants.CodecYAML: "xmlname,created_by,id,name",
+		constants.CodecForm: "XMLName,CreatedBy,ID,user_name",
+	}
+	for codec, names := range want {
+		if got := shape(codec); got != names {
+			t.Fatal(codec, got)
+		}
+	}
+
+	props := s.WireShape(constants.CodecJSON)
+	if !props[2].AsString || props[2].Optional || !props[3].OmitEmpty || !props[3].Optional {
+		t.Fatal(props[2], props[3])
+	}
+	if props[1].Field.Embed != "Audit" || props[1].Field.Depth != 1 {
+		t.Fatal(props[1].Field.Embed)
+	}
+	if xml := s.WireShape(constants.CodecXML); !xml[1].Attr || xml[2].Attr {
+		t.Fatal(xml[1])
+	}
+}